@@ -0,0 +1,239 @@
+// Comando migrate aplica e reverte as migrações SQL do banco de dados.
+//
+// Uso:
+//
+//	migrate up              aplica as migrações pendentes
+//	migrate down [n]        reverte as últimas n migrações (padrão 1)
+//	migrate status          lista as migrações e o estado de cada uma
+//	migrate create <nome>   cria os arquivos de uma nova migração
+//	migrate force <versão>  marca as migrações até a versão como aplicadas
+//	migrate grant-admin <email>  dá o papel admin a um usuário já cadastrado
+//
+// O cadastro pela API só atribui o papel user; grant-admin promove o
+// primeiro administrador, que depois gerencia os papéis pela API.
+//
+// As configurações do banco vêm das mesmas camadas do servidor (CONFIG_FILE,
+// .env e variáveis de ambiente).
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"golang/internal/config"
+	"golang/internal/database"
+	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/repository"
+	"golang/internal/services"
+)
+
+// migrationsDir é o diretório das migrações, relativo à raiz do repositório.
+const migrationsDir = "internal/database/migrations"
+
+// Códigos de saída do processo.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errUsage indica argumentos inválidos.
+var errUsage = errors.New("invalid arguments")
+
+const usage = `Usage: migrate <command> [args]
+
+Commands:
+  up              apply all pending migrations
+  down [n]        revert the last n migrations (default 1)
+  status          list migrations and whether they are applied
+  create <name>   create the up and down files of a new migration
+  force <version> mark migrations up to version as applied, without running them
+  grant-admin <email>
+                  give the admin role to an already registered user
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
+
+// run executa o subcomando e retorna o código de saída.
+func run(args []string, out io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(out, usage)
+		return exitOK
+	}
+
+	err := execute(args[0], args[1:], out)
+
+	switch {
+	case errors.Is(err, errUsage):
+		log.Printf("%v", err)
+		fmt.Fprint(os.Stderr, usage)
+
+		return exitUsage
+	case err != nil:
+		log.Printf("migrate %s failed: %v", args[0], err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// execute executa o subcomando.
+func execute(command string, args []string, out io.Writer) error {
+	// create só gera arquivos e não precisa do banco
+	if command == "create" {
+		if len(args) != 1 {
+			return fmt.Errorf("%w: create takes a migration name", errUsage)
+		}
+
+		upPath, downPath, err := database.CreateMigration(migrationsDir, args[0])
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		fmt.Fprintf(out, "Created %s\nCreated %s\n", upPath, downPath)
+
+		return nil
+	}
+
+	switch command {
+	case "up", "down", "status", "force", "grant-admin":
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger := middleware.NewLoggerWithLevel(cfg.Log.Level)
+
+	// SIGINT e SIGTERM cancelam a migração em andamento, que é revertida
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := database.Connect(ctx, cfg.Database, logger.Logger)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	defer func() {
+		if err := database.Close(db); err != nil {
+			logger.Errorf("Failed to close database: %v", err)
+		}
+	}()
+
+	migrator, err := database.NewMigrator(db, logger.Logger)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	switch command {
+	case "up":
+		count, err := migrator.Up(ctx)
+		fmt.Fprintf(out, "Applied %d migration(s)\n", count)
+
+		return err //nolint:wrapcheck
+	case "down":
+		steps := 1
+
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("%w: down takes a positive number of steps", errUsage)
+			}
+		}
+
+		count, err := migrator.Down(ctx, steps)
+		fmt.Fprintf(out, "Reverted %d migration(s)\n", count)
+
+		return err //nolint:wrapcheck
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		printStatus(out, statuses)
+
+		return nil
+	case "grant-admin":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: grant-admin takes an email", errUsage)
+		}
+
+		user, err := repository.NewGormUserRepository(db).FindByEmail(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find user %s: %w", args[0], err)
+		}
+
+		if err := services.NewRBACService(db).AssignRole(ctx, user.ID, models.RoleAdmin); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		fmt.Fprintf(out, "Granted %s to %s\n", models.RoleAdmin, user.Email)
+
+		return nil
+	default: // force
+		if len(args) != 1 {
+			return fmt.Errorf("%w: force takes a version", errUsage)
+		}
+
+		version, err := strconv.Atoi(args[0])
+		if err != nil || version < 0 {
+			return fmt.Errorf("%w: invalid version %q", errUsage, args[0])
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		fmt.Fprintf(out, "Forced version %d\n", version)
+
+		return nil
+	}
+}
+
+// printStatus imprime o estado das migrações em uma tabela.
+func printStatus(out io.Writer, statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+
+	for _, status := range statuses {
+		state := "pending"
+
+		switch {
+		case status.Unknown:
+			state = "applied (unknown)"
+		case status.ChecksumMismatch:
+			state = "applied (checksum mismatch)"
+		case status.Applied:
+			state = "applied"
+		}
+
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+
+	_ = w.Flush()
+}
@@ -1,43 +1,145 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"golang/internal/api"
 	"golang/internal/config"
 	"golang/internal/database"
 	"golang/internal/middleware"
+	"golang/internal/tracing"
+)
+
+// Códigos de saída do processo.
+const (
+	// exitOK indica um desligamento gracioso completo
+	exitOK = 0
+	// exitStartFailed indica falha ao iniciar ou manter o servidor
+	exitStartFailed = 1
+	// exitShutdownFailed indica um desligamento incompleto (prazo esgotado
+	// ou falha ao liberar recursos)
+	exitShutdownFailed = 2
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run inicia o servidor e o desliga graciosamente ao receber SIGINT ou
+// SIGTERM, retornando o código de saída.
+func run() int {
 	// Carregar configurações
-	cfg, err := config.Load()
+	cfg, err := config.Load(os.Args[1:]...)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		log.Printf("Failed to load config: %v", err)
+		return exitStartFailed
 	}
 
 	// Inicializar logger
-	logger := middleware.NewLogger()
+	logger := middleware.NewLoggerWithLevel(cfg.Log.Level)
+
+	// Inicializar tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, nil)
+	if err != nil {
+		logger.Errorf("Failed to set up tracing: %v", err)
+		return exitStartFailed
+	}
+
+	// Enviar os spans pendentes ao sair
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
 
-	// Conectar ao banco de dados
-	db, err := database.Connect(cfg.Database)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Conectar ao banco de dados, aguardando-o ficar disponível
+	db, err := database.Connect(ctx, cfg.Database, logger.Logger)
 	if err != nil {
-		logger.Fatalf("Failed to connect to database: %v", err)
+		logger.Errorf("Failed to connect to database: %v", err)
+		return exitStartFailed
 	}
 
 	// Criar servidor HTTP
 	server := api.NewServer(cfg, db, logger)
 
 	// Iniciar servidor
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	port := cfg.Server.Port
+
+	serverErr := make(chan error, 1)
+
+	go func() {
+		logger.Infof("Starting server on port %s", port)
+		serverErr <- server.Start(":" + port)
+	}()
+
+	code := exitOK
+
+	select {
+	case err := <-serverErr:
+		logger.Errorf("Failed to start server: %v", err)
+
+		code = exitStartFailed
+	case <-ctx.Done():
+		// Um segundo sinal encerra o processo imediatamente
+		stop()
+
+		logger.Info("Shutdown signal received, draining requests")
+	}
+
+	if err := shutdown(server, cfg.Server, logger); err != nil {
+		logger.Errorf("Graceful shutdown failed: %v", err)
+
+		if code == exitOK {
+			code = exitShutdownFailed
+		}
 	}
 
-	logger.Infof("Starting server on port %s", port)
+	if err := database.Close(db); err != nil {
+		logger.Errorf("Failed to close database: %v", err)
 
-	if err := server.Start(":" + port); err != nil {
-		logger.Fatalf("Failed to start server: %v", err)
+		if code == exitOK {
+			code = exitShutdownFailed
+		}
 	}
+
+	if code == exitOK {
+		logger.Info("Server stopped")
+	}
+
+	return code
+}
+
+// shutdown marca a readiness como falha, espera o balanceador deixar de
+// rotear e então drena as requisições em andamento dentro do prazo.
+func shutdown(server *api.Server, cfg config.ServerConfig, logger *middleware.Logger) error {
+	server.MarkNotReady()
+
+	if cfg.ShutdownDelay > 0 {
+		logger.Infof("Waiting %ds before closing listeners", cfg.ShutdownDelay)
+		time.Sleep(time.Duration(cfg.ShutdownDelay) * time.Second)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		logger.Warnf("Shutdown timeout of %ds exceeded, in-flight requests were interrupted", cfg.ShutdownTimeout)
+	}
+
+	return err
 }
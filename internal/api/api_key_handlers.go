@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateAPIKeyRequest representa a requisição de criação de chave de API.
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Scopes    []string `json:"scopes"`
+	ExpiresIn int      `json:"expires_in" binding:"gte=0"` // em segundos; 0 = sem expiração
+}
+
+// CreateAPIKeyResponse inclui a chave em texto puro, exibida uma única vez.
+type CreateAPIKeyResponse struct {
+	Key string `json:"key"`
+	*models.APIKey
+}
+
+// createAPIKey emite uma nova chave de API.
+func (s *Server) createAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	creator, ok := middleware.CurrentUser(c)
+	if !ok {
+		middleware.AbortWithError(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, i18n.NotAuthenticated))
+		return
+	}
+
+	apiKey := &models.APIKey{
+		Name:   req.Name,
+		Scopes: models.Scopes(req.Scopes),
+	}
+
+	if req.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Second)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	key, err := s.apiKeyService.CreateAPIKey(c.Request.Context(), creator, apiKey)
+	if err != nil {
+		s.handleAPIKeyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		Key:    key,
+		APIKey: apiKey,
+	})
+}
+
+// listAPIKeys lista as chaves de API (sem o segredo).
+func (s *Server) listAPIKeys(c *gin.Context) {
+	keys, err := s.apiKeyService.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		s.handleAPIKeyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": keys,
+	})
+}
+
+// revokeAPIKey revoga uma chave de API.
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := s.apiKeyService.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		s.handleAPIKeyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleAPIKeyError mapeia erros do APIKeyService para respostas HTTP.
+func (s *Server) handleAPIKeyError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		middleware.AbortWithError(c, apierror.NotFound(i18n.APIKeyNotFound))
+		return
+	}
+
+	if errors.Is(err, services.ErrScopeNotHeld) {
+		middleware.AbortWithError(c, apierror.New(http.StatusForbidden, apierror.CodeForbidden, i18n.APIKeyScopeNotHeld).
+			WithDetail(err.Error()))
+
+		return
+	}
+
+	middleware.AbortWithError(c, apierror.Internal(i18n.APIKeyFailed, err))
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginRequest representa a requisição de login.
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest representa a requisição de refresh e de logout.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// login autentica o usuário e emite access e refresh tokens.
+func (s *Server) login(c *gin.Context) {
+	var req LoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	pair, err := s.authService.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		s.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pair)
+}
+
+// refreshToken troca um refresh token por um novo par de tokens.
+func (s *Server) refreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	pair, err := s.authService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		s.handleAuthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pair)
+}
+
+// logout revoga o refresh token informado.
+func (s *Server) logout(c *gin.Context) {
+	var req RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	if err := s.authService.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		s.handleAuthError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// me retorna o usuário autenticado.
+func (s *Server) me(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		middleware.AbortWithError(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, i18n.Unauthenticated))
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// handleAuthError mapeia erros do AuthService para respostas HTTP.
+func (s *Server) handleAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCredentials):
+		middleware.AbortWithError(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidCredentials, i18n.InvalidCredentials))
+	case errors.Is(err, services.ErrInvalidRefreshToken):
+		middleware.AbortWithError(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, i18n.InvalidRefreshToken))
+	case errors.Is(err, services.ErrInactiveUser):
+		middleware.AbortWithError(c, apierror.New(http.StatusForbidden, apierror.CodeInactiveUser, i18n.InactiveUser))
+	default:
+		middleware.AbortWithError(c, apierror.Internal(i18n.AuthFailed, err))
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang/internal/database"
+	"golang/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serviceName identifica a aplicação nas respostas de health check.
+const serviceName = "golang-api"
+
+// HealthResponse é a resposta dos endpoints de health check.
+type HealthResponse struct {
+	health.Report
+	Timestamp time.Time `json:"timestamp"`
+	Service   string    `json:"service"`
+}
+
+// registerHealthChecks registra as checagens de readiness das dependências
+// configuradas. O Redis e as réplicas de leitura são opcionais: o rate
+// limiting segue sem o Redis e as leituras vão para o primário sem réplicas.
+func (s *Server) registerHealthChecks() {
+	if s.db != nil {
+		s.health.Register("database", func(ctx context.Context) error {
+			return database.Ping(ctx, s.db)
+		})
+		s.health.Register("migrations", func(ctx context.Context) error {
+			return database.CheckMigrations(ctx, s.db)
+		})
+
+		// Réplicas fora de rotação não derrubam a readiness: as leituras
+		// seguem no primário
+		for _, replica := range database.Replicas(s.db) {
+			s.health.RegisterOptional("replica:"+replica.Name, func(context.Context) error {
+				return replica.Err()
+			})
+		}
+	}
+
+	if s.redis != nil {
+		s.health.RegisterOptional("redis", func(ctx context.Context) error {
+			return s.redis.Ping(ctx).Err() //nolint:wrapcheck
+		})
+	}
+}
+
+// livez indica que o processo está de pé, sem verificar dependências.
+func (s *Server) livez(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{
+		Report:    health.Report{Status: health.StatusOK},
+		Timestamp: time.Now().UTC(),
+		Service:   serviceName,
+	})
+}
+
+// readyz verifica as dependências e responde 503 se alguma checagem
+// obrigatória falhar ou se o servidor estiver encerrando.
+func (s *Server) readyz(c *gin.Context) {
+	report := s.health.Ready(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, HealthResponse{
+		Report:    report,
+		Timestamp: time.Now().UTC(),
+		Service:   serviceName,
+	})
+}
+
+// healthCheck retorna o status de saúde da aplicação. Mantém o formato e a
+// semântica anteriores a /livez e /readyz: sempre responde 200, e database
+// indica se o banco respondeu ao ping dentro do timeout das checagens.
+func (s *Server) healthCheck(c *gin.Context) {
+	status := gin.H{
+		"status":    "ok",
+		"timestamp": time.Now().UTC(),
+		"service":   serviceName,
+		"database":  "disconnected",
+	}
+
+	if s.db != nil {
+		ctx := c.Request.Context()
+
+		if timeout := time.Duration(s.config.Health.CheckTimeout) * time.Second; timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		if err := database.Ping(ctx, s.db); err == nil {
+			status["database"] = "connected"
+		}
+	}
+
+	c.JSON(http.StatusOK, status)
+}
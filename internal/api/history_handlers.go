@@ -0,0 +1,182 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListConversionsResponse representa uma página do histórico de conversões.
+type ListConversionsResponse struct {
+	Data     []models.Conversion `json:"data"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+}
+
+// listConversionHistory lista o histórico de conversões com filtros e paginação.
+func (s *Server) listConversionHistory(c *gin.Context) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseConversionHistoryFilter(c)
+	if !ok {
+		return
+	}
+
+	conversions, total, err := s.historyService.List(c.Request.Context(), filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		middleware.AbortWithError(c, apierror.Internal(i18n.HistoryFailed, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ListConversionsResponse{
+		Data:     conversions,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// parseConversionHistoryFilter lê os filtros da query, respondendo 400 se
+// algum for inválido.
+func parseConversionHistoryFilter(c *gin.Context) (services.ConversionHistoryFilter, bool) {
+	filter := services.ConversionHistoryFilter{
+		Unit:     c.Query("unit"),
+		FromUnit: c.Query("from_unit"),
+		ToUnit:   c.Query("to_unit"),
+	}
+
+	var ok bool
+
+	if filter.MinValue, ok = parseOptionalQuery(c, "min_value", parseFloat); !ok {
+		return filter, false
+	}
+
+	if filter.MaxValue, ok = parseOptionalQuery(c, "max_value", parseFloat); !ok {
+		return filter, false
+	}
+
+	if filter.Since, ok = parseOptionalQuery(c, "since", parseTime); !ok {
+		return filter, false
+	}
+
+	if filter.Until, ok = parseOptionalQuery(c, "until", parseTime); !ok {
+		return filter, false
+	}
+
+	if filter.UserID, ok = parseOptionalQuery(c, "user_id", parseUint); !ok {
+		return filter, false
+	}
+
+	if filter.APIKeyID, ok = parseOptionalQuery(c, "api_key_id", parseUint); !ok {
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// parseOptionalQuery lê um parâmetro opcional da query com o parser informado,
+// respondendo 400 se ele for inválido.
+func parseOptionalQuery[T any](c *gin.Context, name string, parse func(string) (T, error)) (*T, bool) {
+	raw, exists := c.GetQuery(name)
+	if !exists || raw == "" {
+		return nil, true
+	}
+
+	value, err := parse(raw)
+	if err != nil {
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidParameter, name).WithDetail(err.Error()))
+
+		return nil, false
+	}
+
+	return &value, true
+}
+
+// parseFloat lê um número.
+func parseFloat(raw string) (float64, error) {
+	return strconv.ParseFloat(raw, 64) //nolint:wrapcheck
+}
+
+// parseTime lê uma data no formato RFC 3339.
+func parseTime(raw string) (time.Time, error) {
+	return time.Parse(time.RFC3339, raw) //nolint:wrapcheck
+}
+
+// parseUint lê um ID.
+func parseUint(raw string) (uint, error) {
+	value, err := strconv.ParseUint(raw, 10, 0)
+
+	return uint(value), err //nolint:wrapcheck
+}
+
+// recordConversions grava as conversões no histórico com a identificação de
+// quem as solicitou. Falhas são registradas no log sem afetar a resposta.
+func (s *Server) recordConversions(c *gin.Context, source string, conversions []models.Conversion) {
+	var userID, apiKeyID *uint
+
+	if user, ok := middleware.CurrentUser(c); ok {
+		userID = &user.ID
+	}
+
+	if apiKey, ok := middleware.CurrentAPIKey(c); ok {
+		apiKeyID = &apiKey.ID
+	}
+
+	for i := range conversions {
+		conversions[i].Source = source
+		conversions[i].UserID = userID
+		conversions[i].APIKeyID = apiKeyID
+		conversions[i].ClientIP = c.ClientIP()
+	}
+
+	if err := s.historyService.Record(c.Request.Context(), conversions...); err != nil {
+		s.logger.WithContext(c.Request.Context()).WithField("error", err).Warn("Failed to record conversion history")
+	}
+}
+
+// conversionFromResponse monta o registro de histórico de uma conversão.
+func conversionFromResponse(resp *services.TemperatureConversionResponse) models.Conversion {
+	return models.Conversion{
+		FromUnit:       resp.OriginalUnit,
+		ToUnit:         resp.ConvertedUnit,
+		Value:          resp.OriginalValue,
+		ConvertedValue: resp.ConvertedValue,
+		Precision:      resp.Precision,
+		Rounding:       resp.Rounding,
+	}
+}
+
+// conversionsFromAllResponse monta os registros de histórico de uma conversão
+// para todas as unidades, exceto a própria unidade de origem.
+func conversionsFromAllResponse(resp *services.AllConversionsResponse) []models.Conversion {
+	conversions := make([]models.Conversion, 0, len(resp.Conversions))
+
+	for unit, value := range resp.Conversions {
+		if unit == resp.OriginalUnit {
+			continue
+		}
+
+		conversions = append(conversions, models.Conversion{
+			FromUnit:       resp.OriginalUnit,
+			ToUnit:         unit,
+			Value:          resp.OriginalValue,
+			ConvertedValue: value,
+			Precision:      resp.Precision,
+			Rounding:       resp.Rounding,
+		})
+	}
+
+	return conversions
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AssignRoleRequest representa a requisição de atribuição de papel.
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// listRoles lista os papéis e suas permissões.
+func (s *Server) listRoles(c *gin.Context) {
+	roles, err := s.rbacService.ListRoles(c.Request.Context())
+	if err != nil {
+		s.handleRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": roles,
+	})
+}
+
+// assignUserRole atribui um papel a um usuário.
+func (s *Server) assignUserRole(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req AssignRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	if err := s.rbacService.AssignRole(c.Request.Context(), id, req.Role); err != nil {
+		s.handleRoleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// removeUserRole remove um papel de um usuário.
+func (s *Server) removeUserRole(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := s.rbacService.RemoveRole(c.Request.Context(), id, c.Param("role")); err != nil {
+		s.handleRoleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleRoleError mapeia erros do RBACService para respostas HTTP.
+func (s *Server) handleRoleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		middleware.AbortWithError(c, apierror.NotFound(i18n.UserNotFound))
+	case errors.Is(err, services.ErrRoleNotFound):
+		middleware.AbortWithError(c, apierror.NotFound(i18n.RoleNotFound))
+	default:
+		middleware.AbortWithError(c, apierror.Internal(i18n.RoleFailed, err))
+	}
+}
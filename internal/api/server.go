@@ -2,28 +2,55 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
+	"golang/internal/apierror"
+	"golang/internal/auth"
 	"golang/internal/config"
+	"golang/internal/database"
+	"golang/internal/health"
+	"golang/internal/i18n"
+	"golang/internal/metrics"
 	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/repository"
 	"golang/internal/services"
+	"golang/pkg/utils"
 
 	"gorm.io/gorm"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/redis/go-redis/v9"
 )
 
 // Server representa o servidor HTTP.
 type Server struct {
-	config      *config.Config
-	db          *gorm.DB
-	logger      *middleware.Logger
-	router      *gin.Engine
-	server      *http.Server
-	tempService *services.TemperatureService
+	config         *config.Config
+	db             *gorm.DB
+	logger         *middleware.Logger
+	router         *gin.Engine
+	server         *http.Server
+	tempService    *services.TemperatureService
+	userService    *services.UserService
+	authService    *services.AuthService
+	apiKeyService  *services.APIKeyService
+	historyService *services.ConversionHistoryService
+	rbacService    *services.RBACService
+	tokens         *auth.TokenManager
+	validator      *utils.Validator
+	redis          *redis.Client
+	rateLimits     middleware.RateLimitStore
+	metrics        *metrics.Metrics
+	health         *health.Registry
 }
 
 // NewServer cria uma nova instância do servidor.
@@ -37,68 +64,228 @@ func NewServer(cfg *config.Config, db *gorm.DB, logger *middleware.Logger) *Serv
 
 	router := gin.New()
 
+	var observers []middleware.RequestObserver
+
+	var appMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		appMetrics = newMetrics(db, logger)
+		observers = append(observers, appMetrics)
+	}
+
 	// Aplicar middlewares
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logger, observers...))
+	// Depois do log, para que os pânicos recuperados sejam registrados e medidos
 	router.Use(middleware.RecoveryMiddleware(logger))
-	router.Use(middleware.LoggingMiddleware(logger))
-	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.ErrorHandlerMiddleware(logger))
+	router.Use(middleware.CORSMiddleware(cfg.CORS))
+	router.Use(middleware.LanguageMiddleware())
+
+	if cfg.Auth.JWTSecret == "" {
+		logger.Warn("JWT_SECRET not set, using a random secret; tokens will not survive restarts")
+	}
+
+	tempService := services.NewTemperatureService()
+	if appMetrics != nil {
+		tempService.SetObserver(appMetrics)
+	}
+
+	registerTemperatureUnitValidation(tempService.Units())
+	registerJSONFieldNames()
+
+	userService := services.NewUserService(repository.NewGormUserRepository(db), auth.NewPasswordHasher(cfg.Auth))
+	tokens := auth.NewTokenManager(cfg.Auth)
 
 	server := &Server{
-		config:      cfg,
-		db:          db,
-		logger:      logger,
-		router:      router,
-		tempService: services.NewTemperatureService(),
+		config:        cfg,
+		db:            db,
+		logger:        logger,
+		router:        router,
+		tempService:   tempService,
+		userService:   userService,
+		authService:   services.NewAuthService(db, userService, tokens),
+		apiKeyService: services.NewAPIKeyService(db),
+		historyService: services.NewConversionHistoryService(db, logger.Logger,
+			cfg.Temperature.HistoryAsync, cfg.Temperature.HistoryQueueSize),
+		rbacService: services.NewRBACService(db),
+		tokens:      tokens,
+		validator:   utils.NewValidator(),
+		metrics:     appMetrics,
+		health:      health.NewRegistry(time.Duration(cfg.Health.CheckTimeout) * time.Second),
+	}
+
+	server.server = &http.Server{
+		Handler:      router,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
 	}
 
+	server.setupRateLimitStore()
+	server.registerHealthChecks()
+
 	// Configurar rotas
 	server.setupRoutes()
 
 	return server
 }
 
+// newMetrics cria as métricas da aplicação, incluindo as dos pools de
+// conexões do banco e das réplicas, se houver.
+func newMetrics(db *gorm.DB, logger *middleware.Logger) *metrics.Metrics {
+	appMetrics := metrics.New()
+
+	if db == nil {
+		return appMetrics
+	}
+
+	sqlDB, err := db.DB()
+	if err == nil {
+		err = appMetrics.RegisterDB(sqlDB, "primary")
+	}
+
+	for _, replica := range database.Replicas(db) {
+		if err == nil {
+			err = appMetrics.RegisterDB(replica.DB, "replica:"+replica.Name)
+		}
+	}
+
+	if err != nil {
+		logger.WithField("error", err).Warn("Database pool metrics unavailable")
+	}
+
+	return appMetrics
+}
+
+// setupRateLimitStore escolhe o store do rate limiting conforme a configuração.
+func (s *Server) setupRateLimitStore() {
+	if !s.config.RateLimit.Enabled {
+		return
+	}
+
+	if s.config.RateLimit.Store != "redis" {
+		s.rateLimits = middleware.NewMemoryRateLimitStore()
+		return
+	}
+
+	s.redis = redis.NewClient(&redis.Options{
+		Addr:     net.JoinHostPort(s.config.Redis.Host, s.config.Redis.Port),
+		Password: s.config.Redis.Password,
+		DB:       s.config.Redis.DB,
+	})
+	s.rateLimits = middleware.NewRedisRateLimitStore(s.redis)
+}
+
+// rateLimit retorna o middleware de rate limiting do grupo de rotas, ou um
+// middleware vazio se o rate limiting estiver desativado.
+func (s *Server) rateLimit(group string, limit config.RateLimit) gin.HandlerFunc {
+	if s.rateLimits == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return middleware.RateLimitMiddleware(s.rateLimits, s.logger, group, limit)
+}
+
+// registerTemperatureUnitValidation registra a validação "temperature_unit",
+// que aceita qualquer unidade presente no registro.
+func registerTemperatureUnitValidation(units *services.UnitRegistry) {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		_ = v.RegisterValidation("temperature_unit", func(fl validator.FieldLevel) bool {
+			return units.Has(fl.Field().String())
+		})
+	}
+}
+
+// registerJSONFieldNames faz o validator reportar os campos pelo nome JSON,
+// que é o nome conhecido pelos clientes nos detalhes de erro.
+func registerJSONFieldNames() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+
+			return name
+		})
+	}
+}
+
 // setupRoutes configura as rotas da aplicação.
 func (s *Server) setupRoutes() {
-	// Health check
+	// Health checks
 	s.router.GET("/health", s.healthCheck)
+	s.router.GET("/livez", s.livez)
+	s.router.GET("/readyz", s.readyz)
+
+	// Métricas do Prometheus
+	if s.metrics != nil {
+		s.router.GET(s.config.Metrics.Path, gin.WrapH(s.metrics.Handler()))
+	}
+
+	// API v1 (clientes máquina podem se autenticar por API key)
+	v1 := s.router.Group("/api/v1", middleware.APIKeyAuthMiddleware(s.apiKeyService))
+	limits := s.config.RateLimit
+	defaultLimit := s.rateLimit("default", limits.Default)
 
-	// API v1
-	v1 := s.router.Group("/api/v1")
 	// Exemplo de rota
-	v1.GET("/hello", s.helloHandler)
+	v1.GET("/hello", defaultLimit, s.helloHandler)
+
+	// Rotas protegidas por JWT
+	requireJWT := middleware.JWTAuthMiddleware(s.tokens, s.userService)
+
+	// Rotas protegidas por JWT ou API key
+	requireAuth := middleware.RequireAuth(requireJWT)
 
-	// Rotas de temperatura
-	temperature := v1.Group("/temperature")
+	// Rotas de temperatura (o usuário é identificado, se houver token, para o histórico)
+	temperature := v1.Group("/temperature", middleware.OptionalJWTAuthMiddleware(s.tokens, s.userService),
+		s.rateLimit("temperature", limits.Temperature))
 	temperature.POST("/convert", s.convertTemperature)
+	temperature.POST("/convert/batch", s.convertTemperatureBatch)
 	temperature.GET("/convert/:value/:from_unit", s.convertTemperatureGet)
 	temperature.GET("/convert/:value/:from_unit/all", s.getAllConversions)
-
-	// Adicione mais rotas aqui
-	// users := v1.Group("/users")
-	// users.GET("/", s.getUsers)
-	// users.POST("/", s.createUser)
-	// users.GET("/:id", s.getUser)
-	// users.PUT("/:id", s.updateUser)
-	// users.DELETE("/:id", s.deleteUser)
+	temperature.GET("/history", requireAuth, middleware.RequirePermission(models.PermissionHistoryRead), s.listConversionHistory)
+
+	// Rotas de autenticação (limitadas por IP, antes da autenticação)
+	authRoutes := v1.Group("/auth", s.rateLimit("auth", limits.Auth))
+	authRoutes.POST("/login", s.login)
+	authRoutes.POST("/refresh", s.refreshToken)
+	authRoutes.POST("/logout", s.logout)
+	authRoutes.GET("/me", requireJWT, s.me)
+
+	// Rotas de usuários (o cadastro é público)
+	v1.POST("/users", s.rateLimit("auth", limits.Auth), s.createUser)
+
+	users := v1.Group("/users", requireAuth, s.rateLimit("users", limits.Users))
+	users.GET("", middleware.RequirePermission(models.PermissionUsersRead), s.listUsers)
+	users.GET("/:id", middleware.RequirePermission(models.PermissionUsersRead), s.getUser)
+	users.PUT("/:id", middleware.RequirePermission(models.PermissionUsersWrite), s.updateUser)
+	users.DELETE("/:id", middleware.RequirePermission(models.PermissionUsersDelete), s.deleteUser)
+
+	// Papéis (RBAC)
+	manageRoles := middleware.RequirePermission(models.PermissionRolesManage)
+	v1.GET("/roles", requireJWT, defaultLimit, manageRoles, s.listRoles)
+	users.POST("/:id/roles", manageRoles, s.assignUserRole)
+	users.DELETE("/:id/roles/:role", manageRoles, s.removeUserRole)
+
+	// Administração de API keys (apenas usuários autenticados por JWT)
+	apiKeys := v1.Group("/admin/api-keys", requireJWT, s.rateLimit("admin", limits.Admin),
+		middleware.RequirePermission(models.PermissionAPIKeysManage))
+	apiKeys.GET("", s.listAPIKeys)
+	apiKeys.POST("", s.createAPIKey)
+	apiKeys.DELETE("/:id", s.revokeAPIKey)
+
+	// Rotas e métodos desconhecidos também respondem no formato RFC 7807
+	s.router.HandleMethodNotAllowed = true
+	s.router.NoRoute(func(c *gin.Context) {
+		middleware.AbortWithError(c, apierror.NotFound(i18n.RouteNotFound))
+	})
+	s.router.NoMethod(func(c *gin.Context) {
+		middleware.AbortWithError(c, apierror.New(http.StatusMethodNotAllowed, apierror.CodeMethodNotAllowed, i18n.MethodNotAllowed))
+	})
 } //nolint:wsl
 
-// healthCheck retorna o status de saúde da aplicação.
-func (s *Server) healthCheck(c *gin.Context) {
-	status := gin.H{
-		"status":    "ok",
-		"timestamp": time.Now().UTC(),
-		"service":   "golang-api",
-	}
-
-	// Adicionar status do banco se disponível
-	if s.db != nil {
-		status["database"] = "connected"
-	} else {
-		status["database"] = "disconnected"
-	}
-
-	c.JSON(http.StatusOK, status)
-}
-
 // helloHandler exemplo de handler.
 func (s *Server) helloHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -112,22 +299,20 @@ func (s *Server) convertTemperature(c *gin.Context) {
 	var req services.TemperatureConversionRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Dados inválidos",
-			"details": err.Error(),
-		})
+		middleware.AbortWithError(c, apierror.FromBinding(err))
 		return
 	}
 
-	resp, err := s.tempService.ConvertTemperature(&req)
+	req.Language = middleware.Lang(c)
+
+	resp, err := s.tempService.ConvertTemperature(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Erro ao converter temperatura",
-			"details": err.Error(),
-		})
+		s.handleTemperatureError(c, err)
 		return
 	}
 
+	s.recordConversions(c, models.ConversionSourceSingle, []models.Conversion{conversionFromResponse(resp)})
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -138,36 +323,38 @@ func (s *Server) convertTemperatureGet(c *gin.Context) {
 	toUnit := c.Query("to_unit")
 
 	if toUnit == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Parâmetro 'to_unit' é obrigatório",
-		})
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.MissingParameter, "to_unit"))
 		return
 	}
 
 	value, err := strconv.ParseFloat(valueStr, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Valor inválido",
-			"details": err.Error(),
-		})
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidValue).WithDetail(err.Error()))
+		return
+	}
+
+	precision, ok := parsePrecisionQuery(c)
+	if !ok {
 		return
 	}
 
 	req := services.TemperatureConversionRequest{
-		Value:    value,
-		FromUnit: fromUnit,
-		ToUnit:   toUnit,
+		Value:     value,
+		FromUnit:  fromUnit,
+		ToUnit:    toUnit,
+		Precision: precision,
+		Rounding:  c.Query("rounding"),
+		Language:  middleware.Lang(c),
 	}
 
-	resp, err := s.tempService.ConvertTemperature(&req)
+	resp, err := s.tempService.ConvertTemperature(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Erro ao converter temperatura",
-			"details": err.Error(),
-		})
+		s.handleTemperatureError(c, err)
 		return
 	}
 
+	s.recordConversions(c, models.ConversionSourceSingle, []models.Conversion{conversionFromResponse(resp)})
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -178,53 +365,122 @@ func (s *Server) getAllConversions(c *gin.Context) {
 
 	value, err := strconv.ParseFloat(valueStr, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Valor inválido",
-			"details": err.Error(),
-		})
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidValue).WithDetail(err.Error()))
 		return
 	}
 
-	resp, err := s.tempService.GetAllConversions(value, fromUnit)
+	precision, ok := parsePrecisionQuery(c)
+	if !ok {
+		return
+	}
+
+	opts, err := services.NewConversionOptions(precision, c.Query("rounding"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Erro ao converter temperatura",
-			"details": err.Error(),
-		})
+		s.handleTemperatureError(c, err)
 		return
 	}
 
+	opts.Language = middleware.Lang(c)
+
+	resp, err := s.tempService.GetAllConversionsWithOptions(c.Request.Context(), value, fromUnit, opts)
+	if err != nil {
+		s.handleTemperatureError(c, err)
+		return
+	}
+
+	s.recordConversions(c, models.ConversionSourceAll, conversionsFromAllResponse(resp))
+
 	c.JSON(http.StatusOK, resp)
 }
 
-// Start inicia o servidor HTTP.
-func (s *Server) Start(addr string) error {
-	s.server = &http.Server{
-		Addr:         addr,
-		Handler:      s.router,
-		ReadTimeout:  time.Duration(s.config.Server.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(s.config.Server.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(s.config.Server.IdleTimeout) * time.Second,
+// parsePrecisionQuery lê o parâmetro opcional ?precision=, respondendo 400 se inválido.
+func parsePrecisionQuery(c *gin.Context) (*int, bool) {
+	raw, exists := c.GetQuery("precision")
+	if !exists {
+		return nil, true
+	}
+
+	precision, err := strconv.Atoi(raw)
+	if err != nil {
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidParameter, "precision").WithDetail(err.Error()))
+
+		return nil, false
+	}
+
+	return &precision, true
+}
+
+// handleTemperatureError mapeia erros do TemperatureService para respostas HTTP.
+func (s *Server) handleTemperatureError(c *gin.Context, err error) {
+	middleware.AbortWithError(c, temperatureError(err))
+}
+
+// temperatureError converte um erro de conversão no erro da API correspondente.
+func temperatureError(err error) *apierror.Error {
+	var apiErr *apierror.Error
+
+	switch {
+	case errors.Is(err, services.ErrUnknownUnit):
+		apiErr = apierror.New(http.StatusBadRequest, apierror.CodeUnknownUnit, i18n.UnknownUnit)
+	case errors.Is(err, services.ErrInvalidPrecision):
+		apiErr = apierror.New(http.StatusBadRequest, apierror.CodeInvalidPrecision, i18n.InvalidPrecision)
+	case errors.Is(err, services.ErrInvalidRoundingMode):
+		apiErr = apierror.New(http.StatusBadRequest, apierror.CodeInvalidRoundingMode, i18n.InvalidRoundingMode)
+	case errors.Is(err, services.ErrBelowAbsoluteZero):
+		apiErr = apierror.New(http.StatusUnprocessableEntity, apierror.CodeBelowAbsoluteZero, i18n.BelowAbsoluteZero)
+	case errors.Is(err, services.ErrNonFiniteValue):
+		apiErr = apierror.New(http.StatusUnprocessableEntity, apierror.CodeNonFiniteValue, i18n.NonFiniteValue)
+	default:
+		return apierror.Internal(i18n.ConversionFailed, err)
 	}
 
+	return apiErr.WithDetail(err.Error())
+}
+
+// Start inicia o servidor HTTP e bloqueia até ele ser encerrado. Retorna nil
+// quando o encerramento vem de Shutdown.
+func (s *Server) Start(addr string) error {
+	s.server.Addr = addr
+
 	s.logger.Infof("Server starting on %s", addr)
 
-	if err := s.server.ListenAndServe(); err != nil {
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return fmt.Errorf("failed to start server: %w", err) //nolint:wrapcheck
 	}
 
 	return nil
 }
 
-// Shutdown desliga o servidor graciosamente.
+// MarkNotReady faz a readiness falhar, para que o balanceador pare de enviar
+// tráfego antes do desligamento.
+func (s *Server) MarkNotReady() {
+	s.health.SetShuttingDown()
+}
+
+// Shutdown desliga o servidor graciosamente: marca a readiness como falha,
+// aguarda as requisições em andamento até o prazo do contexto, grava o
+// histórico pendente e fecha a conexão com o Redis. Todas as etapas são
+// executadas mesmo se alguma falhar.
 func (s *Server) Shutdown(ctx context.Context) error {
-	if s.server != nil {
-		if err := s.server.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown server: %w", err) //nolint:wrapcheck
+	s.MarkNotReady()
+
+	var errs []error
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown server: %w", err))
+	}
+
+	if err := s.historyService.Close(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if s.redis != nil {
+		if err := s.redis.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close redis client: %w", err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetRouter retorna o router do servidor (usado para testes).
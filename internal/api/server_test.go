@@ -1,15 +1,21 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"golang/internal/config"
+	"golang/internal/database/dbtest"
 	"golang/internal/middleware"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
@@ -71,6 +77,49 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "ok")
 	assert.Contains(t, w.Body.String(), "golang-api")
+	assert.Contains(t, w.Body.String(), `"database":"disconnected"`)
+
+	// Continua respondendo 200 durante o desligamento, ao contrário de /readyz
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestHealthCheckDatabase testa que /health reflete o ping ao banco
+func TestHealthCheckDatabase(t *testing.T) {
+	cfg := &config.Config{
+		Log:    config.LogConfig{Level: "info"},
+		Health: config.HealthConfig{CheckTimeout: 1},
+	}
+
+	db := dbtest.Open(t)
+	server := NewServer(cfg, db, middleware.NewLogger())
+
+	get := func() *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "/health", http.NoBody)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := get()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"database":"connected"`)
+
+	// Com a conexão fechada o ping falha, mas a resposta continua 200
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	w = get()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"ok"`)
+	assert.Contains(t, w.Body.String(), `"database":"disconnected"`)
 }
 
 // TestHelloHandler testa o endpoint hello
@@ -211,3 +260,751 @@ func TestConvertTemperatureInvalidData(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "error")
 }
+
+// TestCreateUserInvalidEmail testa a validação de email na criação de usuário
+func TestCreateUserInvalidEmail(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	jsonBody := `{"name": "Test", "email": "invalid-email", "password": "Password123"}`
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/users", strings.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Email inválido")
+}
+
+// TestCreateUserWeakPassword testa a validação de senha na criação de usuário
+func TestCreateUserWeakPassword(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	jsonBody := `{"name": "Test", "email": "test@example.com", "password": "weak"}`
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/users", strings.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NotContains(t, w.Body.String(), "weak")
+}
+
+// TestUsersRequireAuth testa que as rotas de usuários exigem autenticação
+func TestUsersRequireAuth(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/v1/users/1", http.NoBody)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+
+	// Token inválido
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/api/v1/users", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer invalid-token")
+
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestInvalidAPIKey testa a rejeição de API keys malformadas
+func TestInvalidAPIKey(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	for _, header := range []string{"Authorization", "X-API-Key"} {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/v1/users", http.NoBody)
+		require.NoError(t, err)
+
+		if header == "Authorization" {
+			req.Header.Set(header, "ApiKey invalid-key")
+		} else {
+			req.Header.Set(header, "invalid-key")
+		}
+
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "ApiKey")
+	}
+}
+
+// TestConvertTemperatureAdditionalUnit testa a conversão para uma escala adicional do registro
+func TestConvertTemperatureAdditionalUnit(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	jsonBody := `{"value": 100.0, "from_unit": "celsius", "to_unit": "rankine"}`
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert", strings.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "671.67")
+}
+
+// TestConvertTemperatureErrors testa o mapeamento dos erros de conversão para status HTTP
+func TestConvertTemperatureErrors(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		{"unknown from unit", "/api/v1/temperature/convert/25/invalid?to_unit=celsius", http.StatusBadRequest},
+		{"unknown to unit", "/api/v1/temperature/convert/25/celsius?to_unit=invalid", http.StatusBadRequest},
+		{"unknown unit all", "/api/v1/temperature/convert/25/invalid/all", http.StatusBadRequest},
+		{"below absolute zero", "/api/v1/temperature/convert/-500/kelvin?to_unit=celsius", http.StatusUnprocessableEntity},
+		{"non-finite value", "/api/v1/temperature/convert/NaN/celsius?to_unit=kelvin", http.StatusUnprocessableEntity},
+		{"infinite value all", "/api/v1/temperature/convert/Inf/celsius/all", http.StatusUnprocessableEntity},
+		{"overflow", "/api/v1/temperature/convert/1e308/celsius?to_unit=fahrenheit", http.StatusUnprocessableEntity},
+		{"overflow all", "/api/v1/temperature/convert/1e308/celsius/all", http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), "GET", tc.path, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			server.GetRouter().ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
+
+// TestConvertTemperatureBatch testa a conversão em lote com erros por item
+func TestConvertTemperatureBatch(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+		Temperature: config.TemperatureConfig{
+			BatchMaxSize: 3,
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	jsonBody := `[
+		{"value": 25.0, "from_unit": "celsius", "to_unit": "fahrenheit"},
+		{"value": -500, "from_unit": "kelvin", "to_unit": "celsius"},
+		{"value": "abc", "from_unit": "celsius", "to_unit": "kelvin"}
+	]`
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert/batch", strings.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp BatchConversionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, 3, resp.Total)
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, 77.0, resp.Results[0].Result.ConvertedValue)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Results[1].Status)
+	assert.Equal(t, http.StatusBadRequest, resp.Results[2].Status)
+}
+
+// TestConvertTemperatureBatchValidation testa que os itens do lote seguem as
+// mesmas regras de validação de POST /convert
+func TestConvertTemperatureBatchValidation(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	items := []string{
+		`{"from_unit": "celsius", "to_unit": "fahrenheit"}`,
+		`{"value": 25, "to_unit": "fahrenheit"}`,
+		`{"value": 25, "from_unit": "invalid", "to_unit": "fahrenheit"}`,
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert/batch",
+		strings.NewReader("["+strings.Join(items, ",")+"]"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp BatchConversionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Failed)
+
+	for i, item := range items {
+		single, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert",
+			strings.NewReader(item))
+		require.NoError(t, err)
+		single.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, single)
+
+		var problem map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+
+		require.NotNil(t, resp.Results[i].Error, item)
+		assert.Equal(t, w.Code, resp.Results[i].Status, item)
+		assert.Equal(t, http.StatusBadRequest, resp.Results[i].Status, item)
+		assert.Equal(t, problem["code"], string(resp.Results[i].Error.Code), item)
+	}
+}
+
+// TestConvertTemperatureBatchNDJSON testa a conversão em lote via NDJSON
+func TestConvertTemperatureBatchNDJSON(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	body := "{\"value\": 10, \"from_unit\": \"celsius\", \"to_unit\": \"kelvin\"}\n\n" +
+		"{\"value\": 100, \"from_unit\": \"celsius\", \"to_unit\": \"unknown\"}\n"
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert/batch", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp BatchConversionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, 283.15, resp.Results[0].Result.ConvertedValue)
+	assert.Equal(t, http.StatusBadRequest, resp.Results[1].Status)
+}
+
+// TestConvertTemperatureBatchTooLarge testa o limite de tamanho do lote
+func TestConvertTemperatureBatchTooLarge(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+		Temperature: config.TemperatureConfig{
+			BatchMaxSize: 1,
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	jsonBody := `[{"value": 1, "from_unit": "celsius", "to_unit": "kelvin"}, {"value": 2, "from_unit": "celsius", "to_unit": "kelvin"}]`
+	req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert/batch", strings.NewReader(jsonBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+// TestConvertTemperaturePrecision testa os parâmetros de precisão e arredondamento nas rotas GET
+func TestConvertTemperaturePrecision(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+		contains string
+	}{
+		{"precision and rounding", "/api/v1/temperature/convert/300/kelvin?to_unit=celsius&precision=4&rounding=half_even", http.StatusOK, `"converted_value":26.85`},
+		{"all with precision", "/api/v1/temperature/convert/25/celsius/all?precision=0", http.StatusOK, `"fahrenheit":77`},
+		{"invalid precision", "/api/v1/temperature/convert/25/celsius?to_unit=kelvin&precision=abc", http.StatusBadRequest, "precision"},
+		{"precision out of range", "/api/v1/temperature/convert/25/celsius?to_unit=kelvin&precision=99", http.StatusBadRequest, "error"},
+		{"invalid rounding", "/api/v1/temperature/convert/25/celsius/all?rounding=up", http.StatusBadRequest, "error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), "GET", tc.path, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			server.GetRouter().ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+			assert.Contains(t, w.Body.String(), tc.contains)
+		})
+	}
+}
+
+func TestLocalizedMessages(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	testCases := []struct {
+		name           string
+		path           string
+		acceptLanguage string
+		language       string
+		field          string
+		expected       string
+	}{
+		{"fallback error", "/api/v1/temperature/convert/abc/celsius?to_unit=kelvin", "", "pt-BR", "title", "Valor inválido"},
+		{"english error", "/api/v1/temperature/convert/abc/celsius?to_unit=kelvin", "en-US,en;q=0.9", "en", "title", "Invalid value"},
+		{"spanish error", "/api/v1/temperature/convert/25/invalid?to_unit=kelvin", "es-AR", "es", "title", "Unidad desconocida"},
+		{"unsupported language", "/api/v1/temperature/convert/abc/celsius?to_unit=kelvin", "de-DE", "pt-BR", "title", "Valor inválido"},
+		{"query overrides header", "/api/v1/temperature/convert/25/celsius?to_unit=celsius&lang=en", "es", "en", "formula", "Same unit, no conversion needed"},
+		{"spanish formula", "/api/v1/temperature/convert/25/celsius?to_unit=celsius&lang=es", "", "es", "formula", "Misma unidad, no se necesita conversión"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), "GET", tc.path, http.NoBody)
+			require.NoError(t, err)
+
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+			server.GetRouter().ServeHTTP(w, req)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+			assert.Equal(t, tc.language, w.Header().Get("Content-Language"))
+			assert.Equal(t, tc.expected, body[tc.field])
+		})
+	}
+}
+
+func TestConversionHistoryRequiresAuth(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/v1/temperature/history", http.NoBody)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Conversões aceitam chamadas anônimas, mas rejeitam tokens inválidos
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/api/v1/temperature/convert/25/celsius?to_unit=kelvin", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer invalid-token")
+
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestParseConversionHistoryFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET",
+		"/history?unit=celsius&min_value=-10&max_value=100.5&since=2024-01-01T00:00:00Z&user_id=7", http.NoBody)
+
+	filter, ok := parseConversionHistoryFilter(c)
+	require.True(t, ok)
+
+	assert.Equal(t, "celsius", filter.Unit)
+	assert.Empty(t, filter.FromUnit)
+	require.NotNil(t, filter.MinValue)
+	assert.Equal(t, -10.0, *filter.MinValue)
+	require.NotNil(t, filter.MaxValue)
+	assert.Equal(t, 100.5, *filter.MaxValue)
+	require.NotNil(t, filter.Since)
+	assert.Equal(t, 2024, filter.Since.Year())
+	assert.Nil(t, filter.Until)
+	require.NotNil(t, filter.UserID)
+	assert.Equal(t, uint(7), *filter.UserID)
+	assert.Nil(t, filter.APIKeyID)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/history?since=yesterday", http.NoBody)
+
+	_, ok = parseConversionHistoryFilter(c)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, c.Writer.Status())
+	require.Len(t, c.Errors, 1)
+	assert.Contains(t, c.Errors.Last().Error(), "yesterday")
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{
+			Level: "info",
+		},
+	}
+
+	logger := middleware.NewLogger()
+	var db *gorm.DB
+	server := NewServer(cfg, db, logger)
+
+	testCases := []struct {
+		name   string
+		body   string
+		code   string
+		fields map[string]string
+	}{
+		{"missing fields", `{"value": 25}`, "validation_failed", map[string]string{"from_unit": "required", "to_unit": "required"}},
+		{"unknown unit", `{"value": 25, "from_unit": "celsius", "to_unit": "invalid"}`, "validation_failed", map[string]string{"to_unit": "temperature_unit"}},
+		{"wrong type", `{"value": "hot", "from_unit": "celsius", "to_unit": "kelvin"}`, "validation_failed", map[string]string{"value": "type"}},
+		{"malformed json", `{"value":`, "invalid_request", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), "POST", "/api/v1/temperature/convert", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Request-ID", "req-123")
+
+			w := httptest.NewRecorder()
+			server.GetRouter().ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+
+			var problem middleware.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+
+			assert.Equal(t, tc.code, string(problem.Code))
+			assert.Equal(t, "/errors/"+tc.code, problem.Type)
+			assert.Equal(t, http.StatusBadRequest, problem.Status)
+			assert.Equal(t, "Dados inválidos", problem.Title)
+			assert.Equal(t, "/api/v1/temperature/convert", problem.Instance)
+			assert.Equal(t, "req-123", problem.RequestID)
+
+			fields := make(map[string]string)
+			for _, field := range problem.Errors {
+				fields[field.Field] = field.Rule
+				assert.NotEmpty(t, field.Message)
+			}
+
+			if tc.fields == nil {
+				assert.Empty(t, fields)
+			} else {
+				assert.Equal(t, tc.fields, fields)
+			}
+		})
+	}
+}
+
+// TestUnmatchedRoutes testa rotas e métodos desconhecidos no formato RFC 7807
+func TestUnmatchedRoutes(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{Level: "info"},
+	}
+
+	server := NewServer(cfg, nil, middleware.NewLogger())
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		code   string
+		title  string
+		allow  string
+	}{
+		{"unknown route", "GET", "/api/v1/missing", http.StatusNotFound, "not_found", "Route not found", ""},
+		{"wrong method", "DELETE", "/api/v1/hello", http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed for this route", "GET"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.path, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Accept-Language", "en")
+
+			w := httptest.NewRecorder()
+			server.GetRouter().ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.allow, w.Header().Get("Allow"))
+
+			var problem middleware.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+
+			assert.Equal(t, tc.code, string(problem.Code))
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, tc.title, problem.Title)
+		})
+	}
+}
+
+// TestMetricsEndpoint testa as métricas HTTP e de conversões em /metrics
+func TestMetricsEndpoint(t *testing.T) {
+	cfg := &config.Config{
+		Log:     config.LogConfig{Level: "info"},
+		Metrics: config.MetricsConfig{Enabled: true, Path: "/metrics"},
+	}
+
+	server := NewServer(cfg, nil, middleware.NewLogger())
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/v1/temperature/convert/100/celsius?to_unit=fahrenheit", http.NoBody)
+	require.NoError(t, err)
+	server.GetRouter().ServeHTTP(httptest.NewRecorder(), req)
+
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/metrics", http.NoBody)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, `golang_api_http_requests_total{method="GET",route="/api/v1/temperature/convert/:value/:from_unit",status="200"} 1`)
+	assert.Contains(t, body, `golang_api_http_request_duration_seconds_count{method="GET",route="/api/v1/temperature/convert/:value/:from_unit",status="200"} 1`)
+	assert.Contains(t, body, `golang_api_temperature_conversions_total{from_unit="celsius",to_unit="fahrenheit"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+// TestPanicIsObserved testa que pânicos recuperados entram no log e nas métricas
+func TestPanicIsObserved(t *testing.T) {
+	cfg := &config.Config{
+		Log:     config.LogConfig{Level: "info"},
+		Metrics: config.MetricsConfig{Enabled: true, Path: "/metrics"},
+	}
+
+	logger := middleware.NewLogger()
+
+	var logs bytes.Buffer
+	logger.SetOutput(&logs)
+
+	server := NewServer(cfg, nil, logger)
+	server.GetRouter().GET("/panic", func(*gin.Context) { panic("boom") })
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "/panic", http.NoBody)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, logs.String(), "HTTP Request")
+	assert.Contains(t, logs.String(), `"status":500`)
+
+	req, err = http.NewRequestWithContext(context.Background(), "GET", "/metrics", http.NoBody)
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+
+	assert.Contains(t, w.Body.String(), `golang_api_http_requests_total{method="GET",route="/panic",status="500"} 1`)
+}
+
+// TestLivenessAndReadiness testa /livez e /readyz, inclusive durante o desligamento
+func TestLivenessAndReadiness(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{Level: "info"},
+	}
+
+	server := NewServer(cfg, nil, middleware.NewLogger())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", path, http.NoBody)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/livez").Code)
+	assert.Equal(t, http.StatusOK, get("/readyz").Code)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	w := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"fail"`)
+	assert.Contains(t, w.Body.String(), "shutting down")
+
+	assert.Equal(t, http.StatusOK, get("/livez").Code)
+}
+
+// TestGracefulShutdown testa que o desligamento aguarda as requisições em andamento
+func TestGracefulShutdown(t *testing.T) {
+	cfg := &config.Config{
+		Log: config.LogConfig{Level: "info"},
+	}
+
+	server := NewServer(cfg, nil, middleware.NewLogger())
+
+	started := make(chan struct{})
+	server.GetRouter().GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+
+	// Reservar uma porta livre
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	startErr := make(chan error, 1)
+
+	go func() {
+		startErr <- server.Start(addr)
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+		}
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	type response struct {
+		status int
+		err    error
+	}
+
+	responses := make(chan response, 1)
+
+	go func() {
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "http://"+addr+"/slow", http.NoBody)
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+
+		_ = resp.Body.Close()
+		responses <- response{status: resp.StatusCode}
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, server.Shutdown(ctx))
+
+	resp := <-responses
+	require.NoError(t, resp.err)
+	assert.Equal(t, http.StatusOK, resp.status)
+	assert.NoError(t, <-startErr)
+}
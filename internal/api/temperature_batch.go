@@ -0,0 +1,207 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// errBatchTooLarge indica que o lote excede o tamanho máximo configurado.
+var errBatchTooLarge = errors.New("batch too large")
+
+// BatchConversionItem representa o resultado de um item do lote. Apenas um
+// entre Result e Error é preenchido.
+type BatchConversionItem struct {
+	Index  int                                     `json:"index"`
+	Status int                                     `json:"status"`
+	Result *services.TemperatureConversionResponse `json:"result,omitempty"`
+	Error  *middleware.Problem                     `json:"error,omitempty"`
+}
+
+// BatchConversionResponse representa a resposta da conversão em lote.
+type BatchConversionResponse struct {
+	Total     int                   `json:"total"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BatchConversionItem `json:"results"`
+}
+
+// convertTemperatureBatch converte um lote de temperaturas. O corpo pode ser
+// um array JSON ou NDJSON (Content-Type: application/x-ndjson), um objeto
+// TemperatureConversionRequest por linha. Erros em um item não afetam os demais.
+func (s *Server) convertTemperatureBatch(c *gin.Context) {
+	maxSize := s.config.Temperature.BatchMaxSize
+	if maxSize <= 0 {
+		maxSize = 1000
+	}
+
+	var (
+		items []json.RawMessage
+		err   error
+	)
+
+	if isNDJSON(c.GetHeader("Content-Type")) {
+		items, err = readNDJSONItems(c.Request.Body, maxSize)
+	} else {
+		items, err = readJSONArrayItems(c.Request.Body, maxSize)
+	}
+
+	if err != nil {
+		if errors.Is(err, errBatchTooLarge) {
+			middleware.AbortWithError(c, apierror.New(http.StatusRequestEntityTooLarge,
+				apierror.CodePayloadTooLarge, i18n.BatchTooLarge, maxSize))
+			return
+		}
+
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	resp := BatchConversionResponse{
+		Total:   len(items),
+		Results: make([]BatchConversionItem, len(items)),
+	}
+
+	var conversions []models.Conversion
+
+	for i, raw := range items {
+		item := s.convertBatchItem(c, raw)
+		item.Index = i
+
+		if item.Error == nil {
+			resp.Succeeded++
+
+			conversions = append(conversions, conversionFromResponse(item.Result))
+		} else {
+			resp.Failed++
+		}
+
+		resp.Results[i] = item
+	}
+
+	s.recordConversions(c, models.ConversionSourceBatch, conversions)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// convertBatchItem decodifica, valida e converte um único item do lote, com
+// as mesmas regras de POST /convert. Os erros do item usam o mesmo formato
+// RFC 7807 das respostas de erro.
+func (s *Server) convertBatchItem(c *gin.Context, raw json.RawMessage) BatchConversionItem {
+	var req services.TemperatureConversionRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return batchItemError(c, apierror.FromBinding(err))
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return batchItemError(c, apierror.FromBinding(err))
+	}
+
+	req.Language = middleware.Lang(c)
+
+	result, err := s.tempService.ConvertTemperature(c.Request.Context(), &req)
+	if err != nil {
+		return batchItemError(c, temperatureError(err))
+	}
+
+	return BatchConversionItem{
+		Status: http.StatusOK,
+		Result: result,
+	}
+}
+
+// batchItemError monta o resultado de um item que falhou.
+func batchItemError(c *gin.Context, apiErr *apierror.Error) BatchConversionItem {
+	problem := middleware.NewProblem(c, apiErr)
+
+	return BatchConversionItem{
+		Status: apiErr.Status,
+		Error:  &problem,
+	}
+}
+
+// isNDJSON indica se o Content-Type corresponde a NDJSON.
+func isNDJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/x-ndjson" || mediaType == "application/ndjson"
+}
+
+// readJSONArrayItems lê um array JSON item a item, sem carregar mais que
+// maxSize itens em memória.
+func readJSONArrayItems(body io.Reader, maxSize int) ([]json.RawMessage, error) {
+	decoder := json.NewDecoder(body)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err) //nolint:wrapcheck
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("batch must be a JSON array") //nolint:err113
+	}
+
+	var items []json.RawMessage
+
+	for decoder.More() {
+		if len(items) == maxSize {
+			return nil, errBatchTooLarge
+		}
+
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			return nil, fmt.Errorf("failed to read batch item %d: %w", len(items), err) //nolint:wrapcheck
+		}
+
+		items = append(items, item)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err) //nolint:wrapcheck
+	}
+
+	return items, nil
+}
+
+// readNDJSONItems lê um item por linha, ignorando linhas em branco.
+func readNDJSONItems(body io.Reader, maxSize int) ([]json.RawMessage, error) {
+	scanner := bufio.NewScanner(body)
+
+	var items []json.RawMessage
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if len(items) == maxSize {
+			return nil, errBatchTooLarge
+		}
+
+		items = append(items, json.RawMessage(bytes.Clone(line)))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err) //nolint:wrapcheck
+	}
+
+	return items, nil
+}
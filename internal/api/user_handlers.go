@@ -0,0 +1,257 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang/internal/apierror"
+	"golang/internal/database"
+	"golang/internal/i18n"
+	"golang/internal/middleware"
+	"golang/internal/models"
+	"golang/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// CreateUserRequest representa a requisição de criação de usuário.
+type CreateUserRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// UpdateUserRequest representa a requisição de atualização de usuário.
+// Campos omitidos permanecem inalterados.
+type UpdateUserRequest struct {
+	Name     *string `json:"name"`
+	Email    *string `json:"email"`
+	Password *string `json:"password"`
+	Active   *bool   `json:"active"`
+}
+
+// ListUsersResponse representa uma página de usuários.
+type ListUsersResponse struct {
+	Data     []models.User `json:"data"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
+
+// createUser cria um novo usuário.
+func (s *Server) createUser(c *gin.Context) {
+	var req CreateUserRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	if !s.validator.IsValidEmail(req.Email) {
+		middleware.AbortWithError(c, apierror.InvalidField(i18n.InvalidEmail, "email", "email"))
+		return
+	}
+
+	if !s.validator.IsValidPassword(req.Password) {
+		middleware.AbortWithError(c, apierror.InvalidField(i18n.WeakPassword, "password", "password"))
+		return
+	}
+
+	role, err := s.rbacService.DefaultRole(c.Request.Context())
+	if err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	// O papel é gravado junto com o usuário, na mesma transação
+	user := &models.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+		Active:   true,
+		Roles:    []models.Role{*role},
+	}
+
+	if err := s.userService.CreateUser(c.Request.Context(), user); err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// getUser retorna um usuário pelo ID.
+func (s *Server) getUser(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := s.userService.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// updateUser atualiza parcialmente um usuário.
+func (s *Server) updateUser(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, apierror.FromBinding(err))
+		return
+	}
+
+	if req.Email != nil && !s.validator.IsValidEmail(*req.Email) {
+		middleware.AbortWithError(c, apierror.InvalidField(i18n.InvalidEmail, "email", "email"))
+		return
+	}
+
+	if req.Password != nil && !s.validator.IsValidPassword(*req.Password) {
+		middleware.AbortWithError(c, apierror.InvalidField(i18n.WeakPassword, "password", "password"))
+		return
+	}
+
+	// Ler do primário para não salvar por cima de uma alteração recente que
+	// ainda não chegou às réplicas
+	ctx := database.WithPrimary(c.Request.Context())
+
+	user, err := s.userService.GetUserByID(ctx, id)
+	if err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+
+	if req.Email != nil {
+		user.Email = *req.Email
+	}
+
+	if req.Password != nil {
+		if err := s.userService.SetPassword(user, *req.Password); err != nil {
+			s.handleUserError(c, err)
+			return
+		}
+	}
+
+	if req.Active != nil {
+		user.Active = *req.Active
+	}
+
+	if err := s.userService.UpdateUser(ctx, user); err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	// Uma nova senha ou a desativação encerra as sessões existentes
+	if req.Password != nil || (req.Active != nil && !*req.Active) {
+		if err := s.authService.RevokeAll(ctx, user.ID); err != nil {
+			s.handleUserError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// deleteUser remove um usuário (soft delete).
+func (s *Server) deleteUser(c *gin.Context) {
+	id, ok := parseIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Garantir 404 para usuários inexistentes ou já removidos
+	if _, err := s.userService.GetUserByID(database.WithPrimary(c.Request.Context()), id); err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	if err := s.userService.DeleteUser(c.Request.Context(), id); err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// listUsers lista usuários com paginação via ?page=&page_size=.
+func (s *Server) listUsers(c *gin.Context) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	users, total, err := s.userService.ListUsers(c.Request.Context(), (page-1)*pageSize, pageSize)
+	if err != nil {
+		s.handleUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListUsersResponse{
+		Data:     users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// parsePagination lê os parâmetros page e page_size, respondendo 400 se inválidos.
+func parsePagination(c *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidParameter, "page"))
+
+		return 0, 0, false
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidParameter, "page_size"))
+
+		return 0, 0, false
+	}
+
+	return page, pageSize, true
+}
+
+// parseIDParam extrai o ID da rota, respondendo 400 se inválido.
+func parseIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		middleware.AbortWithError(c, apierror.BadRequest(i18n.InvalidID))
+
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// handleUserError mapeia erros do UserService para respostas HTTP.
+func (s *Server) handleUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		middleware.AbortWithError(c, apierror.NotFound(i18n.UserNotFound))
+	case errors.Is(err, services.ErrEmailAlreadyExists):
+		middleware.AbortWithError(c, apierror.New(http.StatusConflict, apierror.CodeConflict, i18n.EmailAlreadyExists))
+	default:
+		middleware.AbortWithError(c, apierror.Internal(i18n.UserFailed, err))
+	}
+}
@@ -0,0 +1,161 @@
+// Package apierror define o erro padrão das respostas da API, com código
+// legível por máquina, status HTTP e detalhes por campo.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang/internal/i18n"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Code identifica o tipo de erro de forma estável para os clientes.
+type Code string
+
+// Códigos de erro da API.
+const (
+	CodeInvalidRequest      Code = "invalid_request"
+	CodeValidationFailed    Code = "validation_failed"
+	CodeUnauthorized        Code = "unauthorized"
+	CodeInvalidCredentials  Code = "invalid_credentials"
+	CodeInvalidToken        Code = "invalid_token"
+	CodeInvalidAPIKey       Code = "invalid_api_key"
+	CodeForbidden           Code = "forbidden"
+	CodeInactiveUser        Code = "inactive_user"
+	CodeNotFound            Code = "not_found"
+	CodeMethodNotAllowed    Code = "method_not_allowed"
+	CodeConflict            Code = "conflict"
+	CodePayloadTooLarge     Code = "payload_too_large"
+	CodeRateLimited         Code = "rate_limited"
+	CodeUnknownUnit         Code = "unknown_unit"
+	CodeInvalidPrecision    Code = "invalid_precision"
+	CodeInvalidRoundingMode Code = "invalid_rounding_mode"
+	CodeBelowAbsoluteZero   Code = "below_absolute_zero"
+	CodeNonFiniteValue      Code = "non_finite_value"
+	CodeInternal            Code = "internal_error"
+)
+
+// FieldError descreve a falha de validação de um campo. Tag e Param vêm da
+// regra do validator (p.ex. "min" e "8") e são usados para traduzir a mensagem.
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+// Error é o erro retornado pelos handlers. Message é uma chave do catálogo
+// i18n, traduzida com Args no idioma da requisição; Detail é um texto livre
+// opcional e Err é a causa, registrada no log mas nunca exposta.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Args    []interface{}
+	Detail  string
+	Fields  []FieldError
+	Err     error
+}
+
+// New cria um erro com status, código e chave de mensagem.
+func New(status int, code Code, message string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Message: message, Args: args}
+}
+
+// BadRequest cria um erro 400 genérico.
+func BadRequest(message string, args ...interface{}) *Error {
+	return New(http.StatusBadRequest, CodeInvalidRequest, message, args...)
+}
+
+// InvalidField cria um erro 400 de validação de um único campo.
+func InvalidField(message, field, tag string) *Error {
+	apiErr := New(http.StatusBadRequest, CodeValidationFailed, message)
+	apiErr.Fields = []FieldError{{Field: field, Tag: tag}}
+
+	return apiErr
+}
+
+// NotFound cria um erro 404.
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Internal cria um erro 500 a partir da causa.
+func Internal(message string, err error) *Error {
+	return New(http.StatusInternalServerError, CodeInternal, message).Wrap(err)
+}
+
+// Error implementa a interface error.
+func (e *Error) Error() string {
+	text := string(e.Code)
+	if e.Detail != "" {
+		text += ": " + e.Detail
+	}
+
+	if e.Err != nil {
+		text += ": " + e.Err.Error()
+	}
+
+	return text
+}
+
+// Unwrap retorna a causa.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetail define o texto livre do erro.
+func (e *Error) WithDetail(detail string) *Error {
+	e.Detail = detail
+	return e
+}
+
+// Wrap define a causa do erro.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// FromBinding converte o erro de ShouldBindJSON em um erro 400. Erros do
+// validator viram detalhes por campo; os demais (JSON malformado, tipos
+// incompatíveis) viram um erro genérico com o detalhe do decoder.
+func FromBinding(err error) *Error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		apiErr := New(http.StatusBadRequest, CodeValidationFailed, i18n.InvalidData)
+
+		for _, fieldErr := range validationErrors {
+			apiErr.Fields = append(apiErr.Fields, FieldError{
+				Field: fieldName(fieldErr),
+				Tag:   fieldErr.Tag(),
+				Param: fieldErr.Param(),
+			})
+		}
+
+		return apiErr
+	}
+
+	apiErr := BadRequest(i18n.InvalidData).WithDetail(err.Error())
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		apiErr.Code = CodeValidationFailed
+		apiErr.Fields = []FieldError{{Field: typeErr.Field, Tag: "type", Param: typeErr.Type.String()}}
+	}
+
+	return apiErr
+}
+
+// fieldName retorna o caminho do campo sem o nome da struct raiz. Com o
+// nome JSON registrado no validator, o resultado é "from_unit" e não "FromUnit".
+func fieldName(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if index := strings.Index(namespace, "."); index >= 0 {
+		return namespace[index+1:]
+	}
+
+	return fieldErr.Field()
+}
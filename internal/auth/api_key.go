@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// apiKeyPrefix identifica chaves de API emitidas por este serviço.
+const apiKeyPrefix = "gk"
+
+// GenerateAPIKey gera uma nova chave de API no formato gk_<prefixo>_<segredo>.
+// Retorna a chave completa (exibida uma única vez), o prefixo usado para
+// localizá-la e o hash a ser persistido.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	prefixBytes := make([]byte, 6)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err) //nolint:wrapcheck
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err) //nolint:wrapcheck
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, base64.RawURLEncoding.EncodeToString(secret))
+
+	return key, prefix, HashToken(key), nil
+}
+
+// ParseAPIKey extrai o prefixo de uma chave de API, validando seu formato.
+func ParseAPIKey(key string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || len(parts[1]) != 12 || parts[2] == "" {
+		return "", false
+	}
+
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return "", false
+	}
+
+	return parts[1], true
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, "gk_"+prefix+"_"))
+	assert.Equal(t, HashToken(key), hash)
+	assert.NotContains(t, hash, prefix)
+
+	parsed, ok := ParseAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+}
+
+func TestParseAPIKey_Invalid(t *testing.T) {
+	testCases := []string{
+		"",
+		"gk_",
+		"gk_abc_secret",
+		"xx_0123456789ab_secret",
+		"gk_zzzzzzzzzzzz_secret",
+		"gk_0123456789ab_",
+	}
+
+	for _, key := range testCases {
+		_, ok := ParseAPIKey(key)
+		assert.False(t, ok, key)
+	}
+}
@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang/internal/config"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidHash indica que o hash armazenado não está em um formato reconhecido.
+var ErrInvalidHash = errors.New("invalid password hash")
+
+// PasswordHasher gera e verifica hashes de senha.
+type PasswordHasher interface {
+	// Hash gera o hash codificado de uma senha.
+	Hash(password string) (string, error)
+	// Verify compara uma senha com um hash codificado.
+	Verify(encoded, password string) (bool, error)
+	// NeedsRehash indica se o hash foi gerado com parâmetros desatualizados.
+	NeedsRehash(encoded string) bool
+	// Identifies indica se o hash foi gerado por este algoritmo.
+	Identifies(encoded string) bool
+}
+
+// BcryptHasher implementa PasswordHasher com bcrypt.
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher cria um hasher bcrypt com o custo informado.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &BcryptHasher{Cost: cost}
+}
+
+// Hash gera o hash bcrypt da senha.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err) //nolint:wrapcheck
+	}
+
+	return string(hash), nil
+}
+
+// Verify compara a senha com o hash bcrypt.
+func (h *BcryptHasher) Verify(encoded, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%w: %w", ErrInvalidHash, err) //nolint:wrapcheck
+}
+
+// NeedsRehash indica se o custo do hash difere do configurado.
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.Cost
+}
+
+// Identifies reconhece hashes no formato $2a$, $2b$ ou $2y$.
+func (h *BcryptHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+// Argon2idHasher implementa PasswordHasher com argon2id no formato PHC.
+type Argon2idHasher struct {
+	Memory      uint32 // em KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// NewArgon2idHasher cria um hasher argon2id com os parâmetros informados.
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	return &Argon2idHasher{
+		Memory:      memory,
+		Iterations:  iterations,
+		Parallelism: parallelism,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// Hash gera o hash argon2id da senha.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err) //nolint:wrapcheck
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, h.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify compara a senha com o hash argon2id usando os parâmetros do próprio hash.
+func (h *Argon2idHasher) Verify(encoded, password string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	//nolint:gosec // o tamanho da chave vem de um hash gerado por nós
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash indica se os parâmetros do hash diferem dos configurados.
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, _, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.Memory ||
+		params.Iterations != h.Iterations ||
+		params.Parallelism != h.Parallelism ||
+		uint32(len(key)) != h.KeyLength //nolint:gosec
+}
+
+// Identifies reconhece hashes no formato $argon2id$.
+func (h *Argon2idHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+// decodeArgon2id extrai parâmetros, salt e chave de um hash PHC argon2id.
+func decodeArgon2id(encoded string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return params, salt, key, nil
+}
+
+// MultiHasher gera hashes com o algoritmo principal e aceita, na verificação,
+// hashes de algoritmos legados para permitir migração gradual.
+type MultiHasher struct {
+	primary PasswordHasher
+	legacy  []PasswordHasher
+}
+
+// NewMultiHasher cria um MultiHasher com o hasher principal e os legados.
+func NewMultiHasher(primary PasswordHasher, legacy ...PasswordHasher) *MultiHasher {
+	return &MultiHasher{primary: primary, legacy: legacy}
+}
+
+// Hash gera o hash com o algoritmo principal.
+func (m *MultiHasher) Hash(password string) (string, error) {
+	return m.primary.Hash(password)
+}
+
+// Verify delega a verificação ao hasher que reconhece o formato do hash.
+func (m *MultiHasher) Verify(encoded, password string) (bool, error) {
+	hasher := m.find(encoded)
+	if hasher == nil {
+		return false, ErrInvalidHash
+	}
+
+	return hasher.Verify(encoded, password)
+}
+
+// NeedsRehash indica se o hash não é do algoritmo principal ou está desatualizado.
+func (m *MultiHasher) NeedsRehash(encoded string) bool {
+	if !m.primary.Identifies(encoded) {
+		return true
+	}
+
+	return m.primary.NeedsRehash(encoded)
+}
+
+// Identifies indica se algum dos hashers reconhece o hash.
+func (m *MultiHasher) Identifies(encoded string) bool {
+	return m.find(encoded) != nil
+}
+
+// find retorna o hasher que reconhece o hash, priorizando o principal.
+func (m *MultiHasher) find(encoded string) PasswordHasher {
+	if m.primary.Identifies(encoded) {
+		return m.primary
+	}
+
+	for _, hasher := range m.legacy {
+		if hasher.Identifies(encoded) {
+			return hasher
+		}
+	}
+
+	return nil
+}
+
+// NewPasswordHasher cria o hasher configurado, aceitando o outro algoritmo
+// suportado na verificação para que hashes antigos sejam migrados no login.
+func NewPasswordHasher(cfg config.AuthConfig) PasswordHasher {
+	bcryptHasher := NewBcryptHasher(cfg.BcryptCost)
+	argon2Hasher := NewArgon2idHasher(
+		uint32(cfg.Argon2Memory),     //nolint:gosec
+		uint32(cfg.Argon2Iterations), //nolint:gosec
+		uint8(cfg.Argon2Parallelism), //nolint:gosec
+	)
+
+	if cfg.PasswordHasher == "argon2id" {
+		return NewMultiHasher(argon2Hasher, bcryptHasher)
+	}
+
+	return NewMultiHasher(bcryptHasher, argon2Hasher)
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"testing"
+
+	"golang/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptHasher_HashAndVerify(t *testing.T) {
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.NotEqual(t, "Password123", hash)
+	assert.True(t, hasher.Identifies(hash))
+
+	ok, err := hasher.Verify(hash, "Password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify(hash, "WrongPassword1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestBcryptHasher_NeedsRehash(t *testing.T) {
+	oldHasher := NewBcryptHasher(bcrypt.MinCost)
+	newHasher := NewBcryptHasher(bcrypt.MinCost + 1)
+
+	hash, err := oldHasher.Hash("Password123")
+	require.NoError(t, err)
+
+	assert.False(t, oldHasher.NeedsRehash(hash))
+	assert.True(t, newHasher.NeedsRehash(hash))
+}
+
+func TestArgon2idHasher_HashAndVerify(t *testing.T) {
+	hasher := NewArgon2idHasher(1024, 1, 1)
+
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+	assert.True(t, hasher.Identifies(hash))
+
+	ok, err := hasher.Verify(hash, "Password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify(hash, "WrongPassword1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	oldHasher := NewArgon2idHasher(1024, 1, 1)
+	newHasher := NewArgon2idHasher(2048, 2, 1)
+
+	hash, err := oldHasher.Hash("Password123")
+	require.NoError(t, err)
+
+	assert.False(t, oldHasher.NeedsRehash(hash))
+	assert.True(t, newHasher.NeedsRehash(hash))
+
+	// O hash antigo continua verificável com os parâmetros embutidos
+	ok, err := newHasher.Verify(hash, "Password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestArgon2idHasher_InvalidHash(t *testing.T) {
+	hasher := NewArgon2idHasher(1024, 1, 1)
+
+	_, err := hasher.Verify("$argon2id$invalid", "Password123")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
+
+func TestMultiHasher_MigratesLegacyHashes(t *testing.T) {
+	bcryptHasher := NewBcryptHasher(bcrypt.MinCost)
+	argon2Hasher := NewArgon2idHasher(1024, 1, 1)
+	hasher := NewMultiHasher(argon2Hasher, bcryptHasher)
+
+	legacyHash, err := bcryptHasher.Hash("Password123")
+	require.NoError(t, err)
+
+	ok, err := hasher.Verify(legacyHash, "Password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, hasher.NeedsRehash(legacyHash))
+
+	newHash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.True(t, argon2Hasher.Identifies(newHash))
+	assert.False(t, hasher.NeedsRehash(newHash))
+
+	_, err = hasher.Verify("plain-text", "Password123")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	hasher := NewPasswordHasher(config.AuthConfig{
+		PasswordHasher:    "argon2id",
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$")
+
+	hasher = NewPasswordHasher(config.AuthConfig{
+		PasswordHasher: "bcrypt",
+		BcryptCost:     bcrypt.MinCost,
+	})
+
+	hash, err = hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$2a$")
+}
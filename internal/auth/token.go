@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken indica que o token é inválido, expirado ou foi adulterado.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims representa as claims do access token.
+type Claims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// UserID retorna o ID do usuário contido no subject do token.
+func (c *Claims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	return uint(id), nil
+}
+
+// TokenManager emite e valida access tokens JWT e gera refresh tokens opacos.
+type TokenManager struct {
+	secret     []byte
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewTokenManager cria um TokenManager a partir da configuração.
+// Se nenhum segredo for configurado, um segredo aleatório é gerado.
+func NewTokenManager(cfg config.AuthConfig) *TokenManager {
+	secret := []byte(cfg.JWTSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		// A partir do Go 1.24, rand.Read nunca retorna erro
+		_, _ = rand.Read(secret)
+	}
+
+	return &TokenManager{
+		secret:     secret,
+		issuer:     cfg.JWTIssuer,
+		accessTTL:  time.Duration(cfg.AccessTokenTTL) * time.Second,
+		refreshTTL: time.Duration(cfg.RefreshTokenTTL) * time.Second,
+	}
+}
+
+// AccessTTL retorna a duração dos access tokens.
+func (m *TokenManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+// RefreshTTL retorna a duração dos refresh tokens.
+func (m *TokenManager) RefreshTTL() time.Duration {
+	return m.refreshTTL
+}
+
+// IssueAccessToken emite um access token assinado para o usuário.
+func (m *TokenManager) IssueAccessToken(userID uint, email string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			Issuer:    m.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTTL)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err) //nolint:wrapcheck
+	}
+
+	return token, nil
+}
+
+// ParseAccessToken valida um access token e retorna suas claims.
+func (m *TokenManager) ParseAccessToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err) //nolint:wrapcheck
+	}
+
+	return claims, nil
+}
+
+// GenerateRefreshToken gera um refresh token opaco e o hash a ser persistido.
+func (m *TokenManager) GenerateRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err) //nolint:wrapcheck
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, HashToken(token), nil
+}
+
+// HashToken retorna o hash SHA-256 (hex) de um token opaco.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
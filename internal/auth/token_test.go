@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"testing"
+
+	"golang/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTokenManager(secret string, accessTTL int) *TokenManager {
+	return NewTokenManager(config.AuthConfig{
+		JWTSecret:       secret,
+		JWTIssuer:       "test",
+		AccessTokenTTL:  accessTTL,
+		RefreshTokenTTL: 3600,
+	})
+}
+
+func TestTokenManager_IssueAndParse(t *testing.T) {
+	tokens := newTestTokenManager("secret", 60)
+
+	token, err := tokens.IssueAccessToken(42, "user@example.com")
+	require.NoError(t, err)
+
+	claims, err := tokens.ParseAccessToken(token)
+	require.NoError(t, err)
+
+	userID, err := claims.UserID()
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), userID)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.Equal(t, "test", claims.Issuer)
+}
+
+func TestTokenManager_RejectsInvalidTokens(t *testing.T) {
+	tokens := newTestTokenManager("secret", 60)
+
+	// Assinado com outro segredo
+	other := newTestTokenManager("other-secret", 60)
+	token, err := other.IssueAccessToken(1, "user@example.com")
+	require.NoError(t, err)
+
+	_, err = tokens.ParseAccessToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Expirado
+	expired := newTestTokenManager("secret", -60)
+	token, err = expired.IssueAccessToken(1, "user@example.com")
+	require.NoError(t, err)
+
+	_, err = tokens.ParseAccessToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Malformado
+	_, err = tokens.ParseAccessToken("not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenManager_GenerateRefreshToken(t *testing.T) {
+	tokens := newTestTokenManager("secret", 60)
+
+	token, hash, err := tokens.GenerateRefreshToken()
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, HashToken(token), hash)
+
+	other, _, err := tokens.GenerateRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -1,84 +1,336 @@
+// Package config carrega e valida as configurações da aplicação.
+//
+// As camadas são aplicadas nesta ordem, cada uma sobrescrevendo a anterior:
+// valores padrão, arquivo YAML ou TOML (--config ou CONFIG_FILE), arquivo
+// .env, variáveis de ambiente e flags de linha de comando. O nome de cada
+// variável está na tag env dos campos; a flag correspondente é o mesmo nome
+// em minúsculas, com hífens (DB_HOST vira --db-host).
 package config
 
 import (
 	"os"
-	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
+// Ambientes de execução.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Drivers de banco de dados.
+const (
+	DriverPostgres = "postgres"
+	// DriverSQLite é um banco embutido, para desenvolvimento local e testes
+	DriverSQLite = "sqlite"
+)
+
 // Config representa as configurações da aplicação.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Log      LogConfig
+	// Environment é development, test, staging ou production; em produção
+	// os segredos são obrigatórios
+	Environment string            `yaml:"environment" toml:"environment" env:"APP_ENV"`
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	Temperature TemperatureConfig `yaml:"temperature" toml:"temperature"`
+	CORS        CORSConfig        `yaml:"cors" toml:"cors"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit"`
+	Redis       RedisConfig       `yaml:"redis" toml:"redis"`
+	Metrics     MetricsConfig     `yaml:"metrics" toml:"metrics"`
+	Health      HealthConfig      `yaml:"health" toml:"health"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
 }
 
 // ServerConfig configurações do servidor.
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  int
-	WriteTimeout int
-	IdleTimeout  int
+	Port         string `yaml:"port" toml:"port" env:"PORT"`
+	ReadTimeout  int    `yaml:"read_timeout" toml:"read_timeout" env:"READ_TIMEOUT"`
+	WriteTimeout int    `yaml:"write_timeout" toml:"write_timeout" env:"WRITE_TIMEOUT"`
+	IdleTimeout  int    `yaml:"idle_timeout" toml:"idle_timeout" env:"IDLE_TIMEOUT"`
+	// ShutdownDelay é a espera, em segundos, entre marcar a readiness como
+	// falha e parar de aceitar conexões, para o balanceador deixar de rotear
+	ShutdownDelay int `yaml:"shutdown_delay" toml:"shutdown_delay" env:"SHUTDOWN_DELAY"`
+	// ShutdownTimeout é o prazo, em segundos, para concluir as requisições em
+	// andamento e gravar o histórico pendente
+	ShutdownTimeout int `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
 }
 
 // DatabaseConfig configurações do banco de dados.
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+	// Driver é postgres ou sqlite
+	Driver string `yaml:"driver" toml:"driver" env:"DB_DRIVER"`
+	// SQLitePath é o arquivo do banco com o driver sqlite; ":memory:" mantém
+	// o banco em memória até o processo terminar
+	SQLitePath string `yaml:"sqlite_path" toml:"sqlite_path" env:"DB_SQLITE_PATH"`
+	// URL é uma URL de conexão completa (postgres://...); quando definida,
+	// substitui Host, Port, User, Password, DBName e SSLMode
+	URL      string `yaml:"url" toml:"url" env:"DATABASE_URL"`
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" toml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" toml:"user" env:"DB_USER"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASSWORD"`
+	DBName   string `yaml:"name" toml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE"`
+	// Caminhos do certificado e da chave do cliente e da CA do servidor
+	SSLCert     string `yaml:"sslcert" toml:"sslcert" env:"DB_SSLCERT"`
+	SSLKey      string `yaml:"sslkey" toml:"sslkey" env:"DB_SSLKEY"`
+	SSLRootCert string `yaml:"sslrootcert" toml:"sslrootcert" env:"DB_SSLROOTCERT"`
+	// MaxOpenConns limita as conexões abertas; zero não limita
+	MaxOpenConns    int `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime int `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`    // em segundos
+	ConnMaxIdleTime int `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"` // em segundos
+	// ConnectTimeout é o prazo, em segundos, para o banco aceitar a primeira
+	// conexão na inicialização, com novas tentativas em backoff exponencial
+	ConnectTimeout int `yaml:"connect_timeout" toml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	// LogLevel é o nível dos logs do GORM: silent, error, warn ou info
+	LogLevel string `yaml:"log_level" toml:"log_level" env:"DB_LOG_LEVEL"`
+	// SlowQueryThreshold é a duração, em milissegundos, a partir da qual uma
+	// consulta é registrada como lenta
+	SlowQueryThreshold int `yaml:"slow_query_threshold" toml:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD"`
+	// Replicas são as réplicas de leitura: "host[:porta]", com as credenciais
+	// do primário, ou uma URL postgres:// completa
+	Replicas []string `yaml:"replicas" toml:"replicas" env:"DB_REPLICAS"`
+	// ReplicaCheckInterval é o intervalo, em segundos, entre os health checks
+	// das réplicas
+	ReplicaCheckInterval int `yaml:"replica_check_interval" toml:"replica_check_interval" env:"DB_REPLICA_CHECK_INTERVAL"`
 }
 
 // LogConfig configurações de log.
 type LogConfig struct {
-	Level string
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
 }
 
-// Load carrega as configurações do ambiente.
-func Load() (*Config, error) {
-	// Carregar variáveis de ambiente do arquivo .env se existir
-	_ = godotenv.Load()
+// AuthConfig configurações de autenticação.
+type AuthConfig struct {
+	PasswordHasher    string `yaml:"password_hasher" toml:"password_hasher" env:"PASSWORD_HASHER"` // bcrypt ou argon2id
+	BcryptCost        int    `yaml:"bcrypt_cost" toml:"bcrypt_cost" env:"BCRYPT_COST"`
+	Argon2Memory      int    `yaml:"argon2_memory" toml:"argon2_memory" env:"ARGON2_MEMORY"` // em KiB
+	Argon2Iterations  int    `yaml:"argon2_iterations" toml:"argon2_iterations" env:"ARGON2_ITERATIONS"`
+	Argon2Parallelism int    `yaml:"argon2_parallelism" toml:"argon2_parallelism" env:"ARGON2_PARALLELISM"`
+	JWTSecret         string `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET"`
+	JWTIssuer         string `yaml:"jwt_issuer" toml:"jwt_issuer" env:"JWT_ISSUER"`
+	AccessTokenTTL    int    `yaml:"access_token_ttl" toml:"access_token_ttl" env:"ACCESS_TOKEN_TTL"`    // em segundos
+	RefreshTokenTTL   int    `yaml:"refresh_token_ttl" toml:"refresh_token_ttl" env:"REFRESH_TOKEN_TTL"` // em segundos
+}
+
+// TemperatureConfig configurações das conversões de temperatura.
+type TemperatureConfig struct {
+	BatchMaxSize int `yaml:"batch_max_size" toml:"batch_max_size" env:"TEMPERATURE_BATCH_MAX_SIZE"`
+	// HistoryAsync grava o histórico de conversões em segundo plano
+	HistoryAsync     bool `yaml:"history_async" toml:"history_async" env:"TEMPERATURE_HISTORY_ASYNC"`
+	HistoryQueueSize int  `yaml:"history_queue_size" toml:"history_queue_size" env:"TEMPERATURE_HISTORY_QUEUE_SIZE"`
+}
+
+// CORSConfig configurações de CORS.
+type CORSConfig struct {
+	// AllowedOrigins aceita origens exatas ("https://app.example.com"),
+	// subdomínios curinga ("https://*.example.com") ou "*" para qualquer origem
+	AllowedOrigins   []string `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string `yaml:"exposed_headers" toml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	MaxAge           int      `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"` // em segundos
+	AllowCredentials bool     `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+}
+
+// RateLimit define o orçamento de um grupo de rotas: Requests requisições a
+// cada Period segundos, com rajadas de até Burst requisições. Requests zero
+// desativa o limite do grupo; Burst zero equivale a Requests.
+type RateLimit struct {
+	Requests int `yaml:"requests" toml:"requests" env:"REQUESTS"`
+	Period   int `yaml:"period" toml:"period" env:"PERIOD"` // em segundos
+	Burst    int `yaml:"burst" toml:"burst" env:"BURST"`
+}
 
+// RateLimitConfig configurações de rate limiting, por grupo de rotas. A tag
+// env dos grupos é o prefixo das variáveis do limite (RATE_LIMIT_AUTH_REQUESTS).
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Store é memory (por instância) ou redis (compartilhado entre instâncias)
+	Store       string    `yaml:"store" toml:"store" env:"RATE_LIMIT_STORE"`
+	Default     RateLimit `yaml:"default" toml:"default" env:"RATE_LIMIT_DEFAULT"`
+	Temperature RateLimit `yaml:"temperature" toml:"temperature" env:"RATE_LIMIT_TEMPERATURE"`
+	Auth        RateLimit `yaml:"auth" toml:"auth" env:"RATE_LIMIT_AUTH"`
+	Users       RateLimit `yaml:"users" toml:"users" env:"RATE_LIMIT_USERS"`
+	Admin       RateLimit `yaml:"admin" toml:"admin" env:"RATE_LIMIT_ADMIN"`
+}
+
+// RedisConfig configurações do Redis.
+type RedisConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"REDIS_HOST"`
+	Port     string `yaml:"port" toml:"port" env:"REDIS_PORT"`
+	Password string `yaml:"password" toml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" toml:"db" env:"REDIS_DB"`
+}
+
+// MetricsConfig configurações das métricas do Prometheus.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" toml:"path" env:"METRICS_PATH"`
+}
+
+// HealthConfig configurações das checagens de readiness.
+type HealthConfig struct {
+	CheckTimeout int `yaml:"check_timeout" toml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"` // em segundos, por checagem
+}
+
+// TracingConfig configurações do tracing com OpenTelemetry.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"TRACING_ENABLED"`
+	// Exporter é otlp (OTLP/HTTP) ou stdout
+	Exporter    string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+	// OTLPEndpoint é o host:porta do coletor OTLP/HTTP
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool   `yaml:"otlp_insecure" toml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	// SampleRatio é a fração dos traces iniciados aqui que são amostrados (0 a 1)
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Default retorna as configurações padrão, adequadas para desenvolvimento.
+func Default() *Config {
 	return &Config{
+		Environment: EnvDevelopment,
 		Server: ServerConfig{
-			Port:         getEnv("PORT", "8080"),
-			ReadTimeout:  getEnvAsInt("READ_TIMEOUT", 30),
-			WriteTimeout: getEnvAsInt("WRITE_TIMEOUT", 30),
-			IdleTimeout:  getEnvAsInt("IDLE_TIMEOUT", 60),
+			Port:            "8080",
+			ReadTimeout:     30,
+			WriteTimeout:    30,
+			IdleTimeout:     60,
+			ShutdownDelay:   0,
+			ShutdownTimeout: 30,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			DBName:   getEnv("DB_NAME", "golang_app"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Driver:     DriverPostgres,
+			SQLitePath: "golang_app.db",
+			Host:       "localhost",
+			Port:       "5432",
+			User:       "postgres",
+			Password:   "password",
+			DBName:     "golang_app",
+			SSLMode:    "disable",
+			// Pool e inicialização
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    1800,
+			ConnMaxIdleTime:    300,
+			ConnectTimeout:     30,
+			LogLevel:           "warn",
+			SlowQueryThreshold: 200,
+			// Réplicas de leitura
+			ReplicaCheckInterval: 5,
 		},
 		Log: LogConfig{
-			Level: getEnv("LOG_LEVEL", "info"),
+			Level: "info",
+		},
+		Auth: AuthConfig{
+			PasswordHasher:    "bcrypt",
+			BcryptCost:        12,
+			Argon2Memory:      64 * 1024,
+			Argon2Iterations:  3,
+			Argon2Parallelism: 2,
+			JWTIssuer:         "golang-api",
+			AccessTokenTTL:    900,
+			RefreshTokenTTL:   604800,
 		},
-	}, nil
+		Temperature: TemperatureConfig{
+			BatchMaxSize:     1000,
+			HistoryAsync:     false,
+			HistoryQueueSize: 1000,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{
+				"Content-Type", "Accept", "Accept-Language", "Authorization", "X-API-Key", "X-Request-ID",
+			},
+			ExposedHeaders:   []string{"Content-Language", "X-Request-ID"},
+			MaxAge:           600,
+			AllowCredentials: false,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:     true,
+			Store:       "memory",
+			Default:     RateLimit{Requests: 300, Period: 60},
+			Temperature: RateLimit{Requests: 120, Period: 60, Burst: 20},
+			Auth:        RateLimit{Requests: 10, Period: 60},
+			Users:       RateLimit{Requests: 120, Period: 60},
+			Admin:       RateLimit{Requests: 60, Period: 60},
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: "6379",
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2,
+		},
+		Tracing: TracingConfig{
+			Enabled:      false,
+			Exporter:     "otlp",
+			ServiceName:  "golang-api",
+			OTLPEndpoint: "localhost:4318",
+			OTLPInsecure: true,
+			SampleRatio:  1,
+		},
+	}
 }
 
-// getEnv obtém uma variável de ambiente ou retorna um valor padrão.
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// Load carrega as configurações a partir das camadas descritas no pacote,
+// usando args como flags de linha de comando (normalmente os.Args[1:]).
+// Os problemas de leitura e de validação são retornados todos juntos em um
+// *ValidationError. Com -h, retorna flag.ErrHelp.
+func Load(args ...string) (*Config, error) {
+	// Carregar variáveis de ambiente do arquivo .env se existir; as variáveis
+	// já definidas no ambiente têm prioridade
+	_ = godotenv.Load()
+
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, err
 	}
 
-	return defaultValue
-}
+	cfg := Default()
 
-// getEnvAsInt obtém uma variável de ambiente como inteiro ou retorna um valor padrão.
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
+	var problems []error
+
+	path := flags.configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			problems = append(problems, err)
 		}
 	}
 
-	return defaultValue
+	problems = append(problems, applyValues(cfg, lookupEnv)...)
+	problems = append(problems, applyValues(cfg, flags.lookup)...)
+
+	cfg.RateLimit.normalize()
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+// normalize aplica a rajada padrão (igual a Requests) aos grupos sem Burst.
+func (c *RateLimitConfig) normalize() {
+	for _, limit := range []*RateLimit{&c.Default, &c.Temperature, &c.Auth, &c.Users, &c.Admin} {
+		if limit.Burst <= 0 {
+			limit.Burst = limit.Requests
+		}
+	}
 }
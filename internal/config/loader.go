@@ -0,0 +1,224 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedConfigFile indica um arquivo de configuração com extensão
+// diferente de .yaml, .yml ou .toml.
+var ErrUnsupportedConfigFile = errors.New("unsupported config file extension")
+
+// lookupFunc obtém o valor bruto de uma configuração pelo nome da variável.
+type lookupFunc func(name string) (string, bool)
+
+// lookupEnv obtém uma variável de ambiente. Variáveis vazias são tratadas como
+// ausentes.
+func lookupEnv(name string) (string, bool) {
+	value := os.Getenv(name)
+
+	return value, value != ""
+}
+
+// loadFile decodifica o arquivo YAML ou TOML sobre cfg. Chaves desconhecidas
+// são rejeitadas para que erros de digitação não passem despercebidos.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err) //nolint:wrapcheck
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid config file %s: %w", path, err) //nolint:wrapcheck
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(cfg); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err) //nolint:wrapcheck
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedConfigFile, path) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// field é uma configuração folha com o nome da sua variável de ambiente.
+type field struct {
+	name  string
+	value reflect.Value
+}
+
+// fields lista as configurações folha de cfg. O nome de cada uma é a tag env
+// do campo, precedida das tags env das structs que o contêm.
+func fields(cfg *Config) []field {
+	var result []field
+
+	var walk func(v reflect.Value, prefix string)
+
+	walk = func(v reflect.Value, prefix string) {
+		for i := range v.NumField() {
+			name := v.Type().Field(i).Tag.Get("env")
+			if name != "" {
+				name = prefix + name
+			}
+
+			if v.Field(i).Kind() == reflect.Struct {
+				if name != "" {
+					name += "_"
+				}
+
+				walk(v.Field(i), name)
+
+				continue
+			}
+
+			if name != "" {
+				result = append(result, field{name: name, value: v.Field(i)})
+			}
+		}
+	}
+
+	walk(reflect.ValueOf(cfg).Elem(), "")
+
+	return result
+}
+
+// applyValues sobrescreve as configurações encontradas por lookup, retornando
+// um erro para cada valor inválido.
+func applyValues(cfg *Config, lookup lookupFunc) []error {
+	var problems []error
+
+	for _, f := range fields(cfg) {
+		raw, ok := lookup(f.name)
+		if !ok {
+			continue
+		}
+
+		if err := setValue(f.value, raw); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", f.name, err)) //nolint:wrapcheck
+		}
+	}
+
+	return problems
+}
+
+// setValue converte o valor bruto para o tipo do campo.
+func setValue(v reflect.Value, raw string) error {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw) //nolint:err113
+		}
+
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw) //nolint:err113
+		}
+
+		v.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw) //nolint:err113
+		}
+
+		v.SetFloat(f)
+	case reflect.Slice:
+		v.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type()) //nolint:err113
+	}
+
+	return nil
+}
+
+// splitList divide uma lista separada por vírgulas, ignorando itens vazios.
+func splitList(raw string) []string {
+	items := []string{}
+
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// flagValues guarda os valores brutos das flags de linha de comando.
+type flagValues struct {
+	configFile string
+	values     map[string]string
+}
+
+// lookup obtém o valor de uma flag pelo nome da variável equivalente.
+func (f *flagValues) lookup(name string) (string, bool) {
+	value, ok := f.values[name]
+
+	return value, ok
+}
+
+// flagName converte o nome da variável no nome da flag (DB_HOST -> db-host).
+func flagName(env string) string {
+	return strings.ReplaceAll(strings.ToLower(env), "_", "-")
+}
+
+// parseFlags lê as flags de linha de comando. Há uma flag para cada variável
+// de ambiente, além de --config; os valores só são convertidos ao aplicar as
+// camadas, para que todos os erros sejam reportados juntos.
+func parseFlags(args []string) (*flagValues, error) {
+	result := &flagValues{values: make(map[string]string)}
+
+	fs := flag.NewFlagSet("golang-api", flag.ContinueOnError)
+	fs.StringVar(&result.configFile, "config", "", "arquivo de configuração YAML ou TOML (equivale a CONFIG_FILE)")
+
+	for _, f := range fields(Default()) {
+		name := f.name
+		usage := "equivale a " + name
+		set := func(value string) error {
+			result.values[name] = value
+
+			return nil
+		}
+
+		if f.value.Kind() == reflect.Bool {
+			fs.BoolFunc(flagName(name), usage, set)
+		} else {
+			fs.Func(flagName(name), usage, set)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0)) //nolint:err113
+	}
+
+	return result, nil
+}
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// minJWTSecretLength é o tamanho mínimo do JWT_SECRET em produção.
+const minJWTSecretLength = 32
+
+// ErrCORSWildcardWithCredentials indica a combinação de "*" com credenciais,
+// que expõe respostas autenticadas a qualquer site.
+var ErrCORSWildcardWithCredentials = errors.New("CORS_ALLOWED_ORIGINS cannot contain \"*\" when CORS_ALLOW_CREDENTIALS is enabled")
+
+// ValidationError reúne todos os problemas encontrados ao carregar as
+// configurações.
+type ValidationError struct {
+	Problems []error
+}
+
+// Error lista os problemas, um por linha.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("invalid configuration:")
+
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap permite usar errors.Is e errors.As com cada problema.
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// validator acumula os problemas de validação.
+type validator struct {
+	problems []error
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Errorf(format, args...)) //nolint:err113
+}
+
+func (v *validator) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required", name)
+	}
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.addf("%s must be one of %s, got %q", name, strings.Join(allowed, ", "), value)
+	}
+}
+
+func (v *validator) between(name string, value, minValue, maxValue int) {
+	if value < minValue || value > maxValue {
+		v.addf("%s must be between %d and %d, got %d", name, minValue, maxValue, value)
+	}
+}
+
+func (v *validator) positive(name string, value int) {
+	if value <= 0 {
+		v.addf("%s must be greater than 0, got %d", name, value)
+	}
+}
+
+func (v *validator) nonNegative(name string, value int) {
+	if value < 0 {
+		v.addf("%s must not be negative, got %d", name, value)
+	}
+}
+
+func (v *validator) port(name, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.addf("%s must be a port between 1 and 65535, got %q", name, value)
+	}
+}
+
+func (v *validator) rateLimit(prefix string, limit RateLimit) {
+	v.nonNegative(prefix+"_REQUESTS", limit.Requests)
+	v.nonNegative(prefix+"_BURST", limit.Burst)
+
+	if limit.Requests > 0 {
+		v.positive(prefix+"_PERIOD", limit.Period)
+	}
+}
+
+func (v *validator) file(name, path string) {
+	if path == "" {
+		return
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		v.addf("%s: %v", name, err)
+	}
+}
+
+func (v *validator) database(db DatabaseConfig) {
+	v.oneOf("DB_DRIVER", db.Driver, DriverPostgres, DriverSQLite)
+
+	switch {
+	case db.Driver == DriverSQLite:
+		v.required("DB_SQLITE_PATH", db.SQLitePath)
+
+		if len(db.Replicas) > 0 {
+			v.addf("DB_REPLICAS is not supported with DB_DRIVER=sqlite")
+		}
+	case db.URL != "":
+		if u, err := url.Parse(db.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			v.addf("DATABASE_URL must be a postgres:// or postgresql:// URL")
+		}
+	default:
+		v.required("DB_HOST", db.Host)
+		v.port("DB_PORT", db.Port)
+		v.required("DB_USER", db.User)
+		v.required("DB_NAME", db.DBName)
+		v.oneOf("DB_SSLMODE", db.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	}
+
+	v.file("DB_SSLCERT", db.SSLCert)
+	v.file("DB_SSLKEY", db.SSLKey)
+	v.file("DB_SSLROOTCERT", db.SSLRootCert)
+
+	if (db.SSLCert == "") != (db.SSLKey == "") {
+		v.addf("DB_SSLCERT and DB_SSLKEY must be set together")
+	}
+
+	v.nonNegative("DB_MAX_OPEN_CONNS", db.MaxOpenConns)
+	v.nonNegative("DB_MAX_IDLE_CONNS", db.MaxIdleConns)
+
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		v.addf("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", db.MaxIdleConns, db.MaxOpenConns)
+	}
+
+	v.nonNegative("DB_CONN_MAX_LIFETIME", db.ConnMaxLifetime)
+	v.nonNegative("DB_CONN_MAX_IDLE_TIME", db.ConnMaxIdleTime)
+	v.nonNegative("DB_CONNECT_TIMEOUT", db.ConnectTimeout)
+	v.oneOf("DB_LOG_LEVEL", db.LogLevel, "silent", "error", "warn", "info")
+	v.positive("DB_SLOW_QUERY_THRESHOLD", db.SlowQueryThreshold)
+
+	seen := make(map[string]bool, len(db.Replicas))
+
+	for _, replica := range db.Replicas {
+		if seen[replica] {
+			v.addf("DB_REPLICAS contains %q more than once", replica)
+		}
+
+		seen[replica] = true
+
+		v.replica(replica)
+	}
+
+	v.positive("DB_REPLICA_CHECK_INTERVAL", db.ReplicaCheckInterval)
+}
+
+// replica valida uma entrada de DB_REPLICAS: uma URL postgres:// ou
+// "host[:porta]".
+func (v *validator) replica(replica string) {
+	if strings.Contains(replica, "://") {
+		if u, err := url.Parse(replica); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") || u.Host == "" {
+			v.addf("DB_REPLICAS entries must be host[:port] or a postgres:// URL")
+		}
+
+		return
+	}
+
+	host, port, err := net.SplitHostPort(replica)
+	if err != nil {
+		// Sem porta: usa a do primário
+		host, port = replica, ""
+	}
+
+	if strings.TrimSpace(host) == "" || strings.ContainsAny(host, "/@?# ") {
+		v.addf("DB_REPLICAS entries must be host[:port] or a postgres:// URL, got %q", replica)
+		return
+	}
+
+	if port != "" {
+		v.port("DB_REPLICAS port", port)
+	}
+}
+
+// password retorna a senha do banco, da URL quando definida.
+func (db DatabaseConfig) password() string {
+	if db.URL == "" {
+		return db.Password
+	}
+
+	u, err := url.Parse(db.URL)
+	if err != nil || u.User == nil {
+		return ""
+	}
+
+	password, _ := u.User.Password()
+
+	return password
+}
+
+// validate retorna todos os problemas das configurações.
+func (c *Config) validate() []error {
+	v := &validator{}
+
+	v.oneOf("APP_ENV", c.Environment, EnvDevelopment, EnvTest, EnvStaging, EnvProduction)
+
+	v.port("PORT", c.Server.Port)
+	v.positive("READ_TIMEOUT", c.Server.ReadTimeout)
+	v.positive("WRITE_TIMEOUT", c.Server.WriteTimeout)
+	v.positive("IDLE_TIMEOUT", c.Server.IdleTimeout)
+	v.nonNegative("SHUTDOWN_DELAY", c.Server.ShutdownDelay)
+	v.positive("SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout)
+
+	v.database(c.Database)
+
+	v.oneOf("LOG_LEVEL", c.Log.Level, "debug", "info", "warn", "error")
+
+	v.oneOf("PASSWORD_HASHER", c.Auth.PasswordHasher, "bcrypt", "argon2id")
+	v.between("BCRYPT_COST", c.Auth.BcryptCost, 4, 31)
+	v.positive("ARGON2_MEMORY", c.Auth.Argon2Memory)
+	v.positive("ARGON2_ITERATIONS", c.Auth.Argon2Iterations)
+	v.between("ARGON2_PARALLELISM", c.Auth.Argon2Parallelism, 1, 255)
+	v.required("JWT_ISSUER", c.Auth.JWTIssuer)
+	v.positive("ACCESS_TOKEN_TTL", c.Auth.AccessTokenTTL)
+	v.positive("REFRESH_TOKEN_TTL", c.Auth.RefreshTokenTTL)
+
+	v.positive("TEMPERATURE_BATCH_MAX_SIZE", c.Temperature.BatchMaxSize)
+	v.positive("TEMPERATURE_HISTORY_QUEUE_SIZE", c.Temperature.HistoryQueueSize)
+
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowedOrigins, "*") {
+		v.problems = append(v.problems, ErrCORSWildcardWithCredentials)
+	}
+
+	v.nonNegative("CORS_MAX_AGE", c.CORS.MaxAge)
+
+	v.oneOf("RATE_LIMIT_STORE", c.RateLimit.Store, "memory", "redis")
+	v.rateLimit("RATE_LIMIT_DEFAULT", c.RateLimit.Default)
+	v.rateLimit("RATE_LIMIT_TEMPERATURE", c.RateLimit.Temperature)
+	v.rateLimit("RATE_LIMIT_AUTH", c.RateLimit.Auth)
+	v.rateLimit("RATE_LIMIT_USERS", c.RateLimit.Users)
+	v.rateLimit("RATE_LIMIT_ADMIN", c.RateLimit.Admin)
+
+	if c.RateLimit.Enabled && c.RateLimit.Store == "redis" {
+		v.required("REDIS_HOST", c.Redis.Host)
+		v.port("REDIS_PORT", c.Redis.Port)
+	}
+
+	v.nonNegative("REDIS_DB", c.Redis.DB)
+
+	if !strings.HasPrefix(c.Metrics.Path, "/") {
+		v.addf("METRICS_PATH must start with \"/\", got %q", c.Metrics.Path)
+	}
+
+	v.positive("HEALTH_CHECK_TIMEOUT", c.Health.CheckTimeout)
+
+	v.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, "otlp", "stdout")
+	v.required("TRACING_SERVICE_NAME", c.Tracing.ServiceName)
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.addf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Exporter == "otlp" {
+		v.required("TRACING_OTLP_ENDPOINT", c.Tracing.OTLPEndpoint)
+	}
+
+	if c.Environment == EnvProduction {
+		if len(c.Auth.JWTSecret) < minJWTSecretLength {
+			v.addf("JWT_SECRET must have at least %d characters in production", minJWTSecretLength)
+		}
+
+		if c.Database.Driver == DriverSQLite {
+			v.addf("DB_DRIVER=sqlite is meant for development and tests, not production")
+		} else if password := c.Database.password(); password == "" || password == Default().Database.Password {
+			v.addf("DB_PASSWORD must be set to a non-default value in production")
+		}
+	}
+
+	return v.problems
+}
@@ -1,41 +1,274 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"time"
 
 	"golang/internal/config"
-	"golang/internal/models"
 
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
-// Connect estabelece conexão com o banco de dados.
-func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port, cfg.SSLMode)
+// Limites do backoff entre as tentativas de conexão na inicialização
+// (variáveis para os testes).
+var (
+	connectInitialBackoff = 500 * time.Millisecond
+	connectMaxBackoff     = 10 * time.Second
+)
+
+// sqliteMemory é o caminho do banco SQLite em memória.
+const sqliteMemory = ":memory:"
+
+// pgCannotConnectNow é o código de erro do Postgres enquanto o servidor
+// inicia ou se recupera.
+const pgCannotConnectNow = "57P03"
+
+// DSN monta a URL de conexão a partir das configurações. Os valores são
+// escapados, então senhas com espaços, aspas ou "@" são aceitas. Com
+// DATABASE_URL, ela é usada como base e recebe os caminhos SSL configurados.
+func DSN(cfg config.DatabaseConfig) (string, error) {
+	var u *url.URL
+
+	if cfg.URL != "" {
+		parsed, err := url.Parse(cfg.URL)
+		if err != nil {
+			return "", fmt.Errorf("invalid DATABASE_URL: %w", err) //nolint:wrapcheck
+		}
+
+		u = parsed
+	} else {
+		u = &url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(cfg.User, cfg.Password),
+			Host:   net.JoinHostPort(cfg.Host, cfg.Port),
+			Path:   "/" + cfg.DBName,
+		}
+	}
+
+	query := u.Query()
+	params := map[string]string{
+		"sslmode":     cfg.SSLMode,
+		"sslcert":     cfg.SSLCert,
+		"sslkey":      cfg.SSLKey,
+		"sslrootcert": cfg.SSLRootCert,
+		"TimeZone":    "UTC",
+	}
+
+	for key, value := range params {
+		// Os parâmetros da DATABASE_URL têm prioridade; o sslmode padrão
+		// não se aplica a ela
+		if value == "" || query.Has(key) || (cfg.URL != "" && key == "sslmode") {
+			continue
+		}
+
+		query.Set(key, value)
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// openDialector escolhe o dialeto do GORM conforme DB_DRIVER.
+func openDialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	if cfg.Driver == config.DriverSQLite {
+		return sqlite.Open(SQLiteDSN(cfg)), nil
+	}
+
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	return postgres.Open(dsn), nil
+}
+
+// SQLiteDSN monta a conexão do driver sqlite: chaves estrangeiras
+// verificadas, espera de até 5s por um lock, transações que já começam com o
+// lock de escrita e, em arquivos, journal WAL, que permite leituras durante
+// uma escrita.
+func SQLiteDSN(cfg config.DatabaseConfig) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Set("_txlock", "immediate")
+
+	if cfg.SQLitePath != sqliteMemory {
+		query.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	return cfg.SQLitePath + "?" + query.Encode()
+}
+
+// Connect estabelece conexão com o banco de dados (Postgres ou, com
+// DB_DRIVER=sqlite, um arquivo SQLite), tentando novamente com backoff
+// exponencial até o prazo DB_CONNECT_TIMEOUT enquanto o banco não aceitar
+// conexões. As consultas são registradas em log e geram spans de
+// tracing com o contexto de cada chamada. Com DB_REPLICAS, as leituras vão
+// para as réplicas saudáveis (veja WithPrimary). O schema não é alterado; as
+// migrações são aplicadas pelo comando migrate.
+func Connect(ctx context.Context, cfg config.DatabaseConfig, log *logrus.Logger) (*gorm.DB, error) {
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: newGormLogger(log, parseLogLevel(cfg.LogLevel),
+			time.Duration(cfg.SlowQueryThreshold)*time.Millisecond),
+		// A conexão é verificada abaixo, com novas tentativas
+		DisableAutomaticPing: true,
+		// Violações de unicidade viram gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err) //nolint:wrapcheck
 	}
 
-	// Auto-migrate models
-	if err := autoMigrate(db); err != nil {
-		return nil, fmt.Errorf("failed to auto-migrate: %w", err) //nolint:wrapcheck
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err) //nolint:wrapcheck
+	}
+
+	configurePool(sqlDB, cfg)
+
+	if cfg.Driver == config.DriverSQLite && cfg.SQLitePath == sqliteMemory {
+		// Cada conexão teria o seu próprio banco em memória: manter uma só,
+		// sem expirar
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
+
+	if err := waitForDatabase(ctx, sqlDB, time.Duration(cfg.ConnectTimeout)*time.Second, log); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	if len(cfg.Replicas) > 0 {
+		if err := useReplicas(ctx, db, sqlDB, cfg, log); err != nil {
+			_ = sqlDB.Close()
+			return nil, err
+		}
+	}
+
+	// Spans das consultas, sem os valores dos parâmetros (podem conter dados pessoais)
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
+		// Close também encerra o health check das réplicas
+		_ = Close(db)
+		return nil, fmt.Errorf("failed to install tracing plugin: %w", err) //nolint:wrapcheck
 	}
 
 	return db, nil
 }
 
-// autoMigrate executa as migrações automáticas dos modelos.
-func autoMigrate(db *gorm.DB) error {
-	// Adicione seus modelos aqui para auto-migração
-	if err := db.AutoMigrate(&models.User{}); err != nil {
-		return fmt.Errorf("failed to auto-migrate user model: %w", err) //nolint:wrapcheck
+// configurePool aplica os limites do pool de conexões.
+func configurePool(sqlDB *sql.DB, cfg config.DatabaseConfig) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+}
+
+// useReplicas abre as réplicas de leitura, faz o primeiro health check e
+// inicia as verificações periódicas. Réplicas indisponíveis não impedem a
+// inicialização: ficam fora de rotação até responderem.
+func useReplicas(ctx context.Context, db *gorm.DB, primary *sql.DB, cfg config.DatabaseConfig, log *logrus.Logger) error {
+	replicas, err := openReplicas(cfg)
+	if err != nil {
+		return err
+	}
+
+	set := newReplicaSet(primary, replicas, time.Duration(cfg.ReplicaCheckInterval)*time.Second, log)
+	if err := set.install(db); err != nil {
+		closeReplicas(replicas)
+		return err
+	}
+
+	set.check(ctx)
+	set.start()
+
+	return nil
+}
+
+// pinger é a parte do sql.DB usada para esperar o banco.
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// waitForDatabase tenta o ping até o banco responder, dobrando a espera entre
+// as tentativas. Erros retornados pelo próprio Postgres (senha incorreta,
+// banco inexistente) não são repetidos, exceto enquanto ele inicia. Com
+// timeout zero, faz uma única tentativa.
+func waitForDatabase(ctx context.Context, db pinger, timeout time.Duration, log *logrus.Logger) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	backoff := connectInitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if timeout <= 0 || !retryable(err) {
+			return fmt.Errorf("database unavailable: %w", err) //nolint:wrapcheck
+		}
+
+		log.WithContext(ctx).WithFields(logrus.Fields{
+			"attempt":  attempt,
+			"retry_in": backoff,
+			"error":    err,
+		}).Warn("Database not ready, retrying")
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("database unavailable after %d attempts: %w", attempt, err) //nolint:wrapcheck
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, connectMaxBackoff)
+	}
+}
+
+// retryable indica se vale tentar a conexão de novo.
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgCannotConnectNow
+	}
+
+	return true
+}
+
+// Ping verifica se o banco responde dentro do prazo do contexto.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err) //nolint:wrapcheck
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err) //nolint:wrapcheck
 	}
 
 	return nil
@@ -52,6 +285,13 @@ func Close(db *gorm.DB) error {
 		return fmt.Errorf("failed to get underlying sql.DB: %w", err) //nolint:wrapcheck
 	}
 
+	if set := replicasOf(db); set != nil {
+		if err := set.close(); err != nil {
+			_ = sqlDB.Close()
+			return err
+		}
+	}
+
 	if err := sqlDB.Close(); err != nil {
 		return fmt.Errorf("failed to close database connection: %w", err) //nolint:wrapcheck
 	}
@@ -0,0 +1,105 @@
+// Package dbtest abre bancos de dados isolados e já migrados para os testes.
+//
+// Por padrão, cada teste recebe um arquivo SQLite no seu diretório
+// temporário, então go test ./... não depende de serviços externos. Com
+// DB_DRIVER=postgres, os testes usam o Postgres configurado pelas variáveis
+// DB_* (ou DATABASE_URL), cada um em um schema próprio removido ao final.
+package dbtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang/internal/config"
+	"golang/internal/database"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Open retorna uma conexão exclusiva do teste, com as migrações aplicadas.
+// A conexão é fechada, e o schema do Postgres removido, ao final do teste.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.SQLitePath = filepath.Join(t.TempDir(), "test.db")
+
+	schema := ""
+
+	if os.Getenv("DB_DRIVER") == config.DriverPostgres {
+		loaded, err := config.Load()
+		require.NoError(t, err)
+
+		cfg = loaded.Database
+		cfg.Replicas = nil
+		schema = "test_" + randomSuffix(t)
+		cfg.URL = withSearchPath(t, cfg, schema)
+	}
+
+	ctx := context.Background()
+
+	db, err := database.Connect(ctx, cfg, log)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if schema != "" {
+			_ = db.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE").Error
+		}
+
+		_ = database.Close(db)
+	})
+
+	if schema != "" {
+		// O search_path já aponta para o schema, que passa a valer assim
+		// que é criado
+		require.NoError(t, db.Exec("CREATE SCHEMA "+schema).Error)
+	}
+
+	migrator, err := database.NewMigrator(db, log)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	return db
+}
+
+// withSearchPath retorna a URL de conexão com o schema no search_path.
+func withSearchPath(t testing.TB, cfg config.DatabaseConfig, schema string) string {
+	t.Helper()
+
+	dsn, err := database.DSN(cfg)
+	require.NoError(t, err)
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// randomSuffix gera um sufixo aleatório para o nome do schema.
+func randomSuffix(t testing.TB) string {
+	t.Helper()
+
+	buf := make([]byte, 8)
+	_, err := rand.Read(buf)
+	require.NoError(t, err)
+
+	return hex.EncodeToString(buf)
+}
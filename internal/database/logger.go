@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// gormLogger envia os logs do GORM para o logrus, repassando o contexto da
+// consulta para que os hooks (como o do ID da requisição) sejam aplicados.
+type gormLogger struct {
+	log   *logrus.Logger
+	level logger.LogLevel
+	// slowThreshold é a duração a partir da qual uma consulta é registrada
+	// como lenta
+	slowThreshold time.Duration
+}
+
+// newGormLogger cria o adaptador com o nível e o limite de consulta lenta
+// informados.
+func newGormLogger(log *logrus.Logger, level logger.LogLevel, slowThreshold time.Duration) logger.Interface {
+	return &gormLogger{log: log, level: level, slowThreshold: slowThreshold}
+}
+
+// parseLogLevel converte o nível configurado (silent, error, warn ou info).
+// Níveis desconhecidos usam warn.
+func parseLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "silent":
+		return logger.Silent
+	case "error":
+		return logger.Error
+	case "info":
+		return logger.Info
+	default:
+		return logger.Warn
+	}
+}
+
+// LogMode retorna uma cópia do logger com o nível informado.
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{log: l.log, level: level, slowThreshold: l.slowThreshold}
+}
+
+// Info registra mensagens informativas.
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		l.log.WithContext(ctx).Infof(msg, args...)
+	}
+}
+
+// Warn registra avisos.
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		l.log.WithContext(ctx).Warnf(msg, args...)
+	}
+}
+
+// Error registra erros.
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		l.log.WithContext(ctx).Errorf(msg, args...)
+	}
+}
+
+// Trace registra cada consulta executada: erros como error, consultas lentas
+// como warn e as demais, com o nível info, como info.
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	entry := l.log.WithContext(ctx).WithFields(logrus.Fields{
+		"sql":     sql,
+		"rows":    rows,
+		"latency": elapsed,
+	})
+
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		entry.WithField("error", err).Error("Database query failed")
+	case elapsed > l.slowThreshold && l.level >= logger.Warn:
+		entry.Warn("Slow database query")
+	case l.level >= logger.Info:
+		entry.Info("Database query")
+	}
+}
@@ -0,0 +1,597 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang/internal/config"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// migrationLockID identifica o advisory lock que serializa as migrações
+// entre as instâncias da aplicação.
+const migrationLockID int64 = 7_365_280_114_215_690_561
+
+// migrationFiles são as migrações SQL embutidas no binário.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationFilePattern é o formato dos arquivos de migração:
+// <versão>_<nome>.up.sql e <versão>_<nome>.down.sql, com SQL que roda em
+// todos os bancos. Quando um dialeto precisa de outro SQL, a variante
+// <versão>_<nome>.up.<dialeto>.sql substitui o arquivo comum nele.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)(?:\.(postgres|sqlite))?\.sql$`)
+
+var (
+	// ErrPendingMigrations indica que o schema do banco está desatualizado.
+	ErrPendingMigrations = errors.New("pending database migrations")
+	// ErrInvalidMigration indica um arquivo de migração mal formado ou sem par.
+	ErrInvalidMigration = errors.New("invalid migration")
+	// ErrChecksumMismatch indica que uma migração aplicada foi alterada depois
+	// de aplicada.
+	ErrChecksumMismatch = errors.New("migration checksum mismatch")
+	// ErrUnknownMigration indica uma versão aplicada no banco que não existe
+	// neste binário.
+	ErrUnknownMigration = errors.New("unknown migration")
+)
+
+// Migration é uma migração versionada, com o SQL de aplicação e de reversão.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// Checksum é o SHA-256 do SQL de aplicação e de reversão
+	Checksum string
+}
+
+// MigrationStatus é o estado de uma migração no banco.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Unknown indica uma versão aplicada que não existe neste binário
+	Unknown bool
+	// ChecksumMismatch indica que a migração mudou depois de aplicada
+	ChecksumMismatch bool
+}
+
+// schemaMigration é o registro de uma migração aplicada.
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// TableName especifica o nome da tabela.
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// createSchemaMigrationsSQL cria a tabela de controle das migrações. No
+// SQLite, o tipo datetime faz o driver ler applied_at como time.Time.
+const createSchemaMigrationsSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	checksum text NOT NULL,
+	applied_at %s NOT NULL
+)`
+
+// migrationFile identifica um arquivo de migração, sem o dialeto.
+type migrationFile struct {
+	version   int
+	name      string
+	direction string
+}
+
+// LoadMigrations lê as migrações do diretório para o dialeto (postgres ou
+// sqlite), ordenadas pela versão. Cada versão precisa dos arquivos up e down
+// comuns; as variantes de outros dialetos são ignoradas.
+func LoadMigrations(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err) //nolint:wrapcheck
+	}
+
+	byVersion := make(map[int]*Migration)
+	variants := make(map[migrationFile]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: file name %s", ErrInvalidMigration, entry.Name()) //nolint:wrapcheck
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: version of %s", ErrInvalidMigration, entry.Name()) //nolint:wrapcheck
+		}
+
+		if match[4] != "" && match[4] != dialect {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err) //nolint:wrapcheck
+		}
+
+		// As variantes do dialeto são aplicadas depois de lidos os arquivos comuns
+		if match[4] != "" {
+			variants[migrationFile{version: version, name: match[2], direction: match[3]}] = string(content)
+			continue
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d used by %s and %s", //nolint:wrapcheck
+				ErrInvalidMigration, version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	for file, content := range variants {
+		migration, ok := byVersion[file.version]
+		if !ok || migration.Name != file.name {
+			return nil, fmt.Errorf("%w: %s variant of %04d_%s without the common files", //nolint:wrapcheck
+				ErrInvalidMigration, dialect, file.version, file.name)
+		}
+
+		if file.direction == "up" {
+			migration.Up = content
+		} else {
+			migration.Down = content
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("%w: version %d needs non-empty up and down files", //nolint:wrapcheck
+				ErrInvalidMigration, migration.Version)
+		}
+
+		migration.Checksum = migrationChecksum(migration.Up, migration.Down)
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// migrationChecksum calcula o checksum dos dois sentidos da migração, para
+// que uma reversão alterada depois de aplicada também seja detectada. O
+// separador nulo impede que mover SQL entre os arquivos mantenha o checksum.
+func migrationChecksum(up, down string) string {
+	hash := sha256.New()
+	hash.Write([]byte(up))
+	hash.Write([]byte{0})
+	hash.Write([]byte(down))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// embeddedMigrations retorna as migrações embutidas no binário para o dialeto.
+func embeddedMigrations(dialect string) ([]Migration, error) {
+	dir, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err) //nolint:wrapcheck
+	}
+
+	return LoadMigrations(dir, dialect)
+}
+
+// CreateMigration cria os arquivos up e down de uma nova migração no
+// diretório, com a versão seguinte à maior existente.
+func CreateMigration(dir, name string) (upPath, downPath string, err error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("%w: empty name", ErrInvalidMigration) //nolint:wrapcheck
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read migrations directory: %w", err) //nolint:wrapcheck
+	}
+
+	next := 1
+
+	for _, entry := range entries {
+		if match := migrationFilePattern.FindStringSubmatch(entry.Name()); match != nil {
+			if version, err := strconv.Atoi(match[1]); err == nil && version >= next {
+				next = version + 1
+			}
+		}
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", next, name))
+	upPath, downPath = base+".up.sql", base+".down.sql"
+
+	files := map[string]string{
+		upPath:   "-- Migração " + name + "\n",
+		downPath: "-- Reversão de " + name + "\n",
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil { //nolint:gosec
+			return "", "", fmt.Errorf("failed to create migration: %w", err) //nolint:wrapcheck
+		}
+	}
+
+	return upPath, downPath, nil
+}
+
+// Migrator aplica e reverte as migrações embutidas. No Postgres, as operações
+// que alteram o schema seguram um advisory lock, para que instâncias
+// iniciadas ao mesmo tempo não concorram; no SQLite, o lock de escrita do
+// arquivo já serializa as transações. Cada migração roda em uma transação
+// junto com o seu registro em schema_migrations.
+type Migrator struct {
+	db         *gorm.DB
+	log        *logrus.Logger
+	migrations []Migration
+}
+
+// NewMigrator cria um Migrator com as migrações embutidas no binário para o
+// dialeto da conexão.
+func NewMigrator(db *gorm.DB, log *logrus.Logger) (*Migrator, error) {
+	migrations, err := embeddedMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, log: log, migrations: migrations}, nil
+}
+
+// Up aplica as migrações pendentes e retorna quantas foram aplicadas.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		statuses, err := m.verifiedStatus(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			if status.Applied {
+				continue
+			}
+
+			migration := m.find(status.Version)
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down reverte as últimas steps migrações aplicadas e retorna quantas foram
+// revertidas.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		statuses, err := m.verifiedStatus(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && count < steps; i-- {
+			if !statuses[i].Applied {
+				continue
+			}
+
+			migration := m.find(statuses[i].Version)
+			if err := m.revert(conn, migration); err != nil {
+				return err
+			}
+
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Force marca como aplicadas exatamente as migrações até version, sem
+// executá-las, e atualiza os checksums registrados. Serve para corrigir o
+// registro após uma intervenção manual; version zero limpa o registro.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: version %d", ErrUnknownMigration, version) //nolint:wrapcheck
+	}
+
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("1 = 1").Delete(&schemaMigration{}).Error; err != nil {
+				return fmt.Errorf("failed to clear schema migrations: %w", err) //nolint:wrapcheck
+			}
+
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+
+				if err := tx.Create(newSchemaMigration(migration)).Error; err != nil {
+					return fmt.Errorf("failed to record migration %d: %w", migration.Version, err) //nolint:wrapcheck
+				}
+			}
+
+			return nil
+		})
+	})
+}
+
+// Status retorna o estado de cada migração, incluindo as versões aplicadas
+// que não existem neste binário.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationStatus(m.migrations, applied), nil
+}
+
+// withLock executa fn em uma única conexão que segura o lock das migrações,
+// criando a tabela de controle se preciso.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error { //nolint:wrapcheck
+		if conn.Dialector.Name() != config.DriverPostgres {
+			return m.prepare(conn, fn)
+		}
+
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err) //nolint:wrapcheck
+		}
+
+		defer func() {
+			// Liberar o lock mesmo com o contexto cancelado, já que a conexão
+			// volta para o pool
+			unlock := conn.WithContext(context.WithoutCancel(ctx))
+			if err := unlock.Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+				m.log.WithContext(ctx).WithField("error", err).Warn("Failed to release migration lock")
+			}
+		}()
+
+		return m.prepare(conn, fn)
+	})
+}
+
+// prepare cria a tabela de controle, se preciso, e executa fn.
+func (m *Migrator) prepare(conn *gorm.DB, fn func(conn *gorm.DB) error) error {
+	timestampType := "timestamptz"
+	if conn.Dialector.Name() == config.DriverSQLite {
+		timestampType = "datetime"
+	}
+
+	if err := conn.Exec(fmt.Sprintf(createSchemaMigrationsSQL, timestampType)).Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err) //nolint:wrapcheck
+	}
+
+	return fn(conn)
+}
+
+// verifiedStatus retorna o estado das migrações, recusando continuar se o
+// banco tiver versões desconhecidas ou migrações alteradas após aplicadas.
+func (m *Migrator) verifiedStatus(conn *gorm.DB) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := migrationStatus(m.migrations, applied)
+
+	for _, status := range statuses {
+		switch {
+		case status.Unknown:
+			return nil, fmt.Errorf("%w: version %d (%s) is applied but not in this build", //nolint:wrapcheck
+				ErrUnknownMigration, status.Version, status.Name)
+		case status.ChecksumMismatch:
+			return nil, fmt.Errorf("%w: version %d (%s) changed after being applied", //nolint:wrapcheck
+				ErrChecksumMismatch, status.Version, status.Name)
+		}
+	}
+
+	return statuses, nil
+}
+
+// apply executa a migração e a registra na mesma transação.
+func (m *Migrator) apply(conn *gorm.DB, migration *Migration) error {
+	start := time.Now()
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return tx.Create(newSchemaMigration(*migration)).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err) //nolint:wrapcheck
+	}
+
+	m.log.WithContext(conn.Statement.Context).WithFields(logrus.Fields{
+		"version":  migration.Version,
+		"name":     migration.Name,
+		"duration": time.Since(start),
+	}).Info("Migration applied")
+
+	return nil
+}
+
+// revert reverte a migração e remove o seu registro na mesma transação.
+func (m *Migrator) revert(conn *gorm.DB, migration *Migration) error {
+	start := time.Now()
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return tx.Delete(&schemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert migration %d (%s): %w", migration.Version, migration.Name, err) //nolint:wrapcheck
+	}
+
+	m.log.WithContext(conn.Statement.Context).WithFields(logrus.Fields{
+		"version":  migration.Version,
+		"name":     migration.Name,
+		"duration": time.Since(start),
+	}).Info("Migration reverted")
+
+	return nil
+}
+
+// find busca a migração pela versão.
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+
+	return nil
+}
+
+// newSchemaMigration cria o registro de uma migração aplicada agora.
+func newSchemaMigration(migration Migration) *schemaMigration {
+	return &schemaMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.Checksum,
+		AppliedAt: time.Now().UTC(),
+	}
+}
+
+// appliedMigrations lê os registros de schema_migrations. Sem a tabela,
+// nenhuma migração foi aplicada.
+func appliedMigrations(db *gorm.DB) ([]schemaMigration, error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return nil, nil
+	}
+
+	var applied []schemaMigration
+	if err := db.Order("version").Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema migrations: %w", err) //nolint:wrapcheck
+	}
+
+	return applied, nil
+}
+
+// migrationStatus combina as migrações conhecidas com as aplicadas, em ordem
+// de versão.
+func migrationStatus(migrations []Migration, applied []schemaMigration) []MigrationStatus {
+	records := make(map[int]schemaMigration, len(applied))
+	for _, record := range applied {
+		records[record.Version] = record
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations)+len(applied))
+
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+
+		if record, ok := records[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = record.Checksum != migration.Checksum
+
+			delete(records, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range records {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Unknown:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses
+}
+
+// CheckMigrations verifica se todas as migrações embutidas foram aplicadas.
+// Versões aplicadas desconhecidas são ignoradas, para que instâncias antigas
+// continuem prontas durante um deploy gradual.
+func CheckMigrations(ctx context.Context, db *gorm.DB) error {
+	migrations, err := embeddedMigrations(db.Dialector.Name())
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(db.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+
+	for _, status := range migrationStatus(migrations, applied) {
+		if !status.Applied {
+			pending = append(pending, strconv.Itoa(status.Version))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err) //nolint:wrapcheck
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: versions %s", ErrPendingMigrations, strings.Join(pending, ", ")) //nolint:wrapcheck
+	}
+
+	return nil
+}
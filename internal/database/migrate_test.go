@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"golang/internal/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	postgresMigrations, err := embeddedMigrations(config.DriverPostgres)
+	require.NoError(t, err)
+	require.NotEmpty(t, postgresMigrations)
+
+	sqliteMigrations, err := embeddedMigrations(config.DriverSQLite)
+	require.NoError(t, err)
+	require.Len(t, sqliteMigrations, len(postgresMigrations))
+
+	// As versões são sequenciais a partir de 1 e iguais nos dois dialetos
+	for i, migration := range postgresMigrations {
+		assert.Equal(t, i+1, migration.Version)
+		assert.Len(t, migration.Checksum, 64)
+		assert.Equal(t, migration.Name, sqliteMigrations[i].Name)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":      {Data: []byte("CREATE INDEX a ON t (a);")},
+		"0002_add_index.down.sql":    {Data: []byte("DROP INDEX a;")},
+		"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (a int);")},
+		"0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"README.md":                  {Data: []byte("ignored")},
+	}
+
+	migrations, err := LoadMigrations(fsys, config.DriverPostgres)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_table", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE t (a int);", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE t;", migrations[0].Down)
+	assert.Equal(t, "add_index", migrations[1].Name)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+
+	t.Run("down file is part of the checksum", func(t *testing.T) {
+		fsys["0001_create_table.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE t CASCADE;")}
+
+		changed, err := LoadMigrations(fsys, config.DriverPostgres)
+		require.NoError(t, err)
+		assert.NotEqual(t, migrations[0].Checksum, changed[0].Checksum)
+		assert.Equal(t, migrations[1].Checksum, changed[1].Checksum)
+	})
+}
+
+func TestLoadMigrationsDialectVariants(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_create_table.up.sql":            {Data: []byte("CREATE TABLE t (id bigserial);")},
+		"0001_create_table.up.sqlite.sql":     {Data: []byte("CREATE TABLE t (id integer);")},
+		"0001_create_table.down.sql":          {Data: []byte("DROP TABLE t;")},
+		"0001_create_table.down.postgres.sql": {Data: []byte("DROP TABLE t CASCADE;")},
+	}
+
+	postgres, err := LoadMigrations(fsys, config.DriverPostgres)
+	require.NoError(t, err)
+	require.Len(t, postgres, 1)
+	assert.Equal(t, "CREATE TABLE t (id bigserial);", postgres[0].Up)
+	assert.Equal(t, "DROP TABLE t CASCADE;", postgres[0].Down)
+
+	sqlite, err := LoadMigrations(fsys, config.DriverSQLite)
+	require.NoError(t, err)
+	require.Len(t, sqlite, 1)
+	assert.Equal(t, "CREATE TABLE t (id integer);", sqlite[0].Up)
+	assert.Equal(t, "DROP TABLE t;", sqlite[0].Down)
+
+	// O checksum é o do SQL que roda no dialeto
+	assert.NotEqual(t, postgres[0].Checksum, sqlite[0].Checksum)
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "empty up",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql":   {Data: []byte("  \n")},
+				"0001_a.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name: "bad file name",
+			fsys: fstest.MapFS{"create_table.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "version zero",
+			fsys: fstest.MapFS{
+				"0000_a.up.sql":   {Data: []byte("SELECT 1;")},
+				"0000_a.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name: "variant without common files",
+			fsys: fstest.MapFS{"0001_a.up.postgres.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "unknown dialect",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql":       {Data: []byte("SELECT 1;")},
+				"0001_a.down.sql":     {Data: []byte("SELECT 1;")},
+				"0001_a.up.mysql.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name: "duplicated version",
+			fsys: fstest.MapFS{
+				"0001_a.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_a.down.sql": {Data: []byte("SELECT 1;")},
+				"0001_b.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_b.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fsys, config.DriverPostgres)
+			require.ErrorIs(t, err, ErrInvalidMigration)
+		})
+	}
+}
+
+func TestMigrationStatus(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "a", Checksum: "aaa"},
+		{Version: 2, Name: "b", Checksum: "bbb"},
+		{Version: 3, Name: "c", Checksum: "ccc"},
+	}
+
+	appliedAt := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	applied := []schemaMigration{
+		{Version: 1, Name: "a", Checksum: "aaa", AppliedAt: appliedAt},
+		{Version: 2, Name: "b", Checksum: "changed", AppliedAt: appliedAt},
+		{Version: 7, Name: "future", Checksum: "fff", AppliedAt: appliedAt},
+	}
+
+	statuses := migrationStatus(migrations, applied)
+	require.Len(t, statuses, 4)
+
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, appliedAt, *statuses[0].AppliedAt)
+	assert.False(t, statuses[0].ChecksumMismatch)
+
+	assert.True(t, statuses[1].ChecksumMismatch)
+
+	assert.False(t, statuses[2].Applied)
+	assert.Nil(t, statuses[2].AppliedAt)
+
+	assert.Equal(t, 7, statuses[3].Version)
+	assert.True(t, statuses[3].Unknown)
+	assert.Equal(t, "future", statuses[3].Name)
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+
+	upPath, downPath, err := CreateMigration(dir, "Create Users")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0001_create_users.up.sql"), upPath)
+	assert.Equal(t, filepath.Join(dir, "0001_create_users.down.sql"), downPath)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0009_other.up.sql"), []byte("SELECT 1;"), 0o600))
+
+	upPath, _, err = CreateMigration(dir, "add-email-index")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0010_add_email_index.up.sql"), upPath)
+
+	_, _, err = CreateMigration(dir, "--")
+	require.ErrorIs(t, err, ErrInvalidMigration)
+}
+
+func TestMigratorSQLite(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.SQLitePath = filepath.Join(t.TempDir(), "test.db")
+
+	ctx := context.Background()
+
+	db, err := Connect(ctx, cfg, log)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = Close(db) })
+
+	migrator, err := NewMigrator(db, log)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, CheckMigrations(ctx, db), ErrPendingMigrations)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(migrator.migrations), applied)
+	require.NoError(t, CheckMigrations(ctx, db))
+
+	var roles int64
+	require.NoError(t, db.Table("roles").Count(&roles).Error)
+	assert.Equal(t, int64(2), roles)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+
+	for _, status := range statuses {
+		assert.True(t, status.Applied)
+		require.NotNil(t, status.AppliedAt)
+		assert.WithinDuration(t, time.Now(), *status.AppliedAt, time.Minute)
+	}
+
+	reverted, err := migrator.Down(ctx, len(migrator.migrations))
+	require.NoError(t, err)
+	assert.Equal(t, applied, reverted)
+	assert.False(t, db.Migrator().HasTable("users"))
+
+	// Reaplicar depois de reverter tudo
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasTable("users"))
+}
@@ -0,0 +1,104 @@
+-- Schema inicial. Os nomes de tabelas, índices e constraints seguem os gerados
+-- pelo AutoMigrate do GORM, e IF NOT EXISTS permite aplicar a migração sobre
+-- bancos criados por ele.
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    email text NOT NULL,
+    name text NOT NULL,
+    password text NOT NULL,
+    active boolean DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_name ON permissions (name);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles (name);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id bigint,
+    permission_id bigint,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id),
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id bigint,
+    role_id bigint,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    token_hash text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    replaced_by bigint,
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    prefix text NOT NULL,
+    key_hash text NOT NULL,
+    scopes text,
+    created_by_id bigint,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+
+CREATE TABLE IF NOT EXISTS conversions (
+    id bigserial PRIMARY KEY,
+    from_unit text NOT NULL,
+    to_unit text NOT NULL,
+    value decimal NOT NULL,
+    converted_value decimal NOT NULL,
+    "precision" bigint,
+    rounding text,
+    source text NOT NULL,
+    user_id bigint,
+    api_key_id bigint,
+    client_ip text,
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversions_from_unit ON conversions (from_unit);
+CREATE INDEX IF NOT EXISTS idx_conversions_to_unit ON conversions (to_unit);
+CREATE INDEX IF NOT EXISTS idx_conversions_value ON conversions (value);
+CREATE INDEX IF NOT EXISTS idx_conversions_user_id ON conversions (user_id);
+CREATE INDEX IF NOT EXISTS idx_conversions_api_key_id ON conversions (api_key_id);
+CREATE INDEX IF NOT EXISTS idx_conversions_created_at ON conversions (created_at);
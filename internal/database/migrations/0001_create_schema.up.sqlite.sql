@@ -0,0 +1,105 @@
+-- Schema inicial para o SQLite. Segue a migração comum, com os tipos nativos
+-- do SQLite: integer PRIMARY KEY AUTOINCREMENT no lugar de bigserial, datetime
+-- no lugar de timestamptz (o driver lê esse tipo como time.Time) e real no
+-- lugar de decimal. Os nomes de índices e constraints são os mesmos.
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    email text NOT NULL,
+    name text NOT NULL,
+    password text NOT NULL,
+    active boolean DEFAULT true,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    description text,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_name ON permissions (name);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    description text,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles (name);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id bigint,
+    permission_id bigint,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id),
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id bigint,
+    role_id bigint,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id bigint NOT NULL,
+    token_hash text NOT NULL,
+    expires_at datetime NOT NULL,
+    revoked_at datetime,
+    replaced_by bigint,
+    created_at datetime
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    prefix text NOT NULL,
+    key_hash text NOT NULL,
+    scopes text,
+    created_by_id bigint,
+    expires_at datetime,
+    last_used_at datetime,
+    revoked_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+
+CREATE TABLE IF NOT EXISTS conversions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    from_unit text NOT NULL,
+    to_unit text NOT NULL,
+    value real NOT NULL,
+    converted_value real NOT NULL,
+    "precision" bigint,
+    rounding text,
+    source text NOT NULL,
+    user_id bigint,
+    api_key_id bigint,
+    client_ip text,
+    created_at datetime
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversions_from_unit ON conversions (from_unit);
+CREATE INDEX IF NOT EXISTS idx_conversions_to_unit ON conversions (to_unit);
+CREATE INDEX IF NOT EXISTS idx_conversions_value ON conversions (value);
+CREATE INDEX IF NOT EXISTS idx_conversions_user_id ON conversions (user_id);
+CREATE INDEX IF NOT EXISTS idx_conversions_api_key_id ON conversions (api_key_id);
+CREATE INDEX IF NOT EXISTS idx_conversions_created_at ON conversions (created_at);
@@ -0,0 +1,10 @@
+DELETE FROM role_permissions
+WHERE role_id IN (SELECT id FROM roles WHERE name IN ('admin', 'user'));
+
+DELETE FROM user_roles
+WHERE role_id IN (SELECT id FROM roles WHERE name IN ('admin', 'user'));
+
+DELETE FROM roles WHERE name IN ('admin', 'user');
+
+DELETE FROM permissions
+WHERE name IN ('*', 'users:read', 'users:write', 'users:delete', 'roles:manage', 'api-keys:manage', 'history:read');
@@ -0,0 +1,23 @@
+-- Permissões e papéis padrão. O papel admin recebe a permissão curinga "*";
+-- o papel user não tem permissões extras.
+
+INSERT INTO permissions (name, description, created_at, updated_at) VALUES
+    ('*', 'Todas as permissões', now(), now()),
+    ('users:read', 'Listar e consultar usuários', now(), now()),
+    ('users:write', 'Atualizar usuários', now(), now()),
+    ('users:delete', 'Remover usuários', now(), now()),
+    ('roles:manage', 'Gerenciar papéis de usuários', now(), now()),
+    ('api-keys:manage', 'Emitir e revogar API keys', now(), now()),
+    ('history:read', 'Consultar o histórico de conversões', now(), now())
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, created_at, updated_at) VALUES
+    ('admin', now(), now()),
+    ('user', now(), now())
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM roles, permissions
+WHERE roles.name = 'admin' AND permissions.name = '*'
+ON CONFLICT DO NOTHING;
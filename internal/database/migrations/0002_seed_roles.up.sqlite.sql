@@ -0,0 +1,24 @@
+-- Permissões e papéis padrão para o SQLite, que não tem a função now().
+-- O papel admin recebe a permissão curinga "*"; o papel user não tem
+-- permissões extras.
+
+INSERT INTO permissions (name, description, created_at, updated_at) VALUES
+    ('*', 'Todas as permissões', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('users:read', 'Listar e consultar usuários', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('users:write', 'Atualizar usuários', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('users:delete', 'Remover usuários', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('roles:manage', 'Gerenciar papéis de usuários', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('api-keys:manage', 'Emitir e revogar API keys', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('history:read', 'Consultar o histórico de conversões', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, created_at, updated_at) VALUES
+    ('admin', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('user', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM roles, permissions
+WHERE roles.name = 'admin' AND permissions.name = '*'
+ON CONFLICT DO NOTHING;
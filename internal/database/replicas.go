@@ -0,0 +1,362 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang/internal/config"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // driver "pgx" das réplicas
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicaSetName identifica o conjunto de réplicas entre os plugins do GORM.
+const replicaSetName = "golang:replicas"
+
+// errReplicaNotChecked é o estado de uma réplica antes do primeiro health check.
+var errReplicaNotChecked = errors.New("replica not checked yet")
+
+// primaryKey marca no contexto as leituras que devem ir para o primário.
+type primaryKey struct{}
+
+// WithPrimary retorna um contexto cujas leituras vão para o primário em vez
+// das réplicas. Use quando a leitura precisa ver uma escrita recente
+// (read-your-writes), que pode ainda não ter chegado às réplicas. Sem
+// réplicas configuradas, não tem efeito.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// usesPrimary indica se o contexto foi marcado com WithPrimary.
+func usesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Replica é uma réplica de leitura configurada em DB_REPLICAS.
+type Replica struct {
+	// Name é o host e a porta da réplica, sem credenciais
+	Name string
+	DB   *sql.DB
+
+	pinger pinger
+
+	mu  sync.RWMutex
+	err error
+}
+
+// newReplica cria uma réplica que só entra em rotação após o primeiro
+// health check.
+func newReplica(name string, db *sql.DB) *Replica {
+	return &Replica{Name: name, DB: db, pinger: db, err: errReplicaNotChecked}
+}
+
+// Err retorna o erro do último health check; nil se a réplica está em rotação.
+func (r *Replica) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.err
+}
+
+// Healthy indica se a réplica está em rotação.
+func (r *Replica) Healthy() bool {
+	return r.Err() == nil
+}
+
+// setErr registra o resultado do health check e indica se o estado mudou.
+func (r *Replica) setErr(err error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := (r.err == nil) != (err == nil)
+	r.err = err
+
+	return changed
+}
+
+// replicaSet distribui as leituras entre as réplicas saudáveis, em rodízio,
+// e verifica cada uma periodicamente. Sem réplica saudável, as leituras vão
+// para o primário. Escritas e transações vão sempre para o primário.
+type replicaSet struct {
+	primary  *sql.DB
+	replicas []*Replica
+	interval time.Duration
+	log      *logrus.Logger
+
+	next      atomic.Uint64
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newReplicaSet cria o conjunto de réplicas; as verificações começam em start.
+func newReplicaSet(primary *sql.DB, replicas []*Replica, interval time.Duration, log *logrus.Logger) *replicaSet {
+	return &replicaSet{
+		primary:  primary,
+		replicas: replicas,
+		interval: interval,
+		log:      log,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Name implementa gorm.Plugin, para Replicas e Close acharem o conjunto.
+func (s *replicaSet) Name() string {
+	return replicaSetName
+}
+
+// Initialize implementa gorm.Plugin.
+func (s *replicaSet) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// Resolve implementa dbresolver.Policy. O primário vai na lista do resolver
+// só para a política ser consultada mesmo com uma única réplica; a escolha
+// usa o estado dos health checks.
+func (s *replicaSet) Resolve([]gorm.ConnPool) gorm.ConnPool {
+	start := s.next.Add(1)
+
+	for i := range uint64(len(s.replicas)) {
+		replica := s.replicas[(start+i)%uint64(len(s.replicas))]
+		if replica.Healthy() {
+			return replica.DB
+		}
+	}
+
+	return s.primary
+}
+
+// install registra o roteamento no GORM: leituras nas réplicas, escritas e
+// transações no primário, e leituras no primário com WithPrimary.
+func (s *replicaSet) install(db *gorm.DB) error {
+	dialectors := []gorm.Dialector{postgres.New(postgres.Config{Conn: s.primary})}
+	for _, replica := range s.replicas {
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: replica.DB}))
+	}
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors, Policy: s})); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err) //nolint:wrapcheck
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Query().After("gorm:db_resolver").Register("golang:use_primary", s.usePrimary); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err) //nolint:wrapcheck
+	}
+
+	if err := callbacks.Row().After("gorm:db_resolver").Register("golang:use_primary", s.usePrimary); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err) //nolint:wrapcheck
+	}
+
+	if err := callbacks.Raw().After("gorm:db_resolver").Register("golang:use_primary", s.usePrimary); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err) //nolint:wrapcheck
+	}
+
+	if err := db.Use(s); err != nil {
+		return fmt.Errorf("failed to install read replicas: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// usePrimary envia ao primário as leituras de contextos marcados com
+// WithPrimary. Dentro de transações a conexão já é a do primário.
+func (s *replicaSet) usePrimary(db *gorm.DB) {
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+
+	if db.Statement.Context != nil && usesPrimary(db.Statement.Context) {
+		db.Statement.ConnPool = s.primary
+	}
+}
+
+// check faz o ping de todas as réplicas em paralelo, tirando de rotação as
+// que não respondem dentro do intervalo e devolvendo as que voltaram.
+func (s *replicaSet) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, replica := range s.replicas {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := replica.pinger.PingContext(ctx)
+			if !replica.setErr(err) {
+				return
+			}
+
+			entry := s.log.WithField("replica", replica.Name)
+			if err != nil {
+				entry.WithField("error", err).Warn("Read replica unavailable, removed from rotation")
+			} else {
+				entry.Info("Read replica available, added to rotation")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// start verifica as réplicas a cada intervalo até close.
+func (s *replicaSet) start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.check(context.Background())
+			}
+		}
+	}()
+}
+
+// close interrompe as verificações e fecha as conexões das réplicas. Pode
+// ser chamado mais de uma vez.
+func (s *replicaSet) close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+
+		var errs []error
+
+		for _, replica := range s.replicas {
+			if err := replica.DB.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close replica %s: %w", replica.Name, err))
+			}
+		}
+
+		s.closeErr = errors.Join(errs...)
+	})
+
+	return s.closeErr
+}
+
+// openReplicas abre os pools das réplicas, com os mesmos limites do primário.
+// As conexões são estabelecidas sob demanda; a disponibilidade é verificada
+// pelos health checks.
+func openReplicas(cfg config.DatabaseConfig) ([]*Replica, error) {
+	replicas := make([]*Replica, 0, len(cfg.Replicas))
+
+	for _, entry := range cfg.Replicas {
+		dsn, err := ReplicaDSN(cfg, entry)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, err
+		}
+
+		sqlDB, err := sql.Open("pgx", dsn)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to open replica: %w", err) //nolint:wrapcheck
+		}
+
+		configurePool(sqlDB, cfg)
+
+		replicas = append(replicas, newReplica(replicaName(dsn), sqlDB))
+	}
+
+	return replicas, nil
+}
+
+// closeReplicas fecha os pools abertos quando a inicialização falha.
+func closeReplicas(replicas []*Replica) {
+	for _, replica := range replicas {
+		_ = replica.DB.Close()
+	}
+}
+
+// ReplicaDSN monta a URL de conexão de uma entrada de DB_REPLICAS. Uma URL
+// completa é usada como DATABASE_URL; "host[:porta]" reaproveita usuário,
+// senha, banco e SSL do primário, e a porta dele quando omitida.
+func ReplicaDSN(cfg config.DatabaseConfig, replica string) (string, error) {
+	if strings.Contains(replica, "://") {
+		cfg.URL = replica
+		return DSN(cfg)
+	}
+
+	host, port, err := net.SplitHostPort(replica)
+	if err != nil {
+		host, port = replica, ""
+	}
+
+	if cfg.URL == "" {
+		cfg.Host = host
+		if port != "" {
+			cfg.Port = port
+		}
+
+		return DSN(cfg)
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid DATABASE_URL: %w", err) //nolint:wrapcheck
+	}
+
+	if port == "" {
+		port = u.Port()
+	}
+
+	u.Host = host
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	}
+
+	cfg.URL = u.String()
+
+	return DSN(cfg)
+}
+
+// replicaName retorna o host e a porta da URL, sem as credenciais.
+func replicaName(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "replica"
+	}
+
+	return u.Host
+}
+
+// Replicas retorna as réplicas de leitura configuradas em Connect.
+func Replicas(db *gorm.DB) []*Replica {
+	if set := replicasOf(db); set != nil {
+		return set.replicas
+	}
+
+	return nil
+}
+
+// replicasOf retorna o conjunto de réplicas instalado no GORM, se houver.
+func replicasOf(db *gorm.DB) *replicaSet {
+	if db == nil || db.Config == nil {
+		return nil
+	}
+
+	set, _ := db.Config.Plugins[replicaSetName].(*replicaSet)
+
+	return set
+}
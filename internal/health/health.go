@@ -0,0 +1,172 @@
+// Package health implementa as verificações de liveness e readiness da
+// aplicação a partir de um registro de checagens das dependências.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status possíveis de uma checagem ou do relatório.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrShuttingDown indica que a aplicação está encerrando e não deve receber
+// novas requisições.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// CheckFunc verifica uma dependência, respeitando o prazo do contexto.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult é o resultado de uma checagem.
+type CheckResult struct {
+	Status string `json:"status"`
+	// LatencyMS é a duração da checagem, em milissegundos
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+	// Optional indica que a falha não torna a aplicação indisponível
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Report é o resultado de todas as checagens.
+type Report struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// check é uma checagem registrada.
+type check struct {
+	name     string
+	fn       CheckFunc
+	optional bool
+}
+
+// Registry guarda as checagens de readiness. É seguro para uso concorrente.
+type Registry struct {
+	mu           sync.RWMutex
+	checks       []check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry cria um registro cujas checagens expiram após timeout.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adiciona uma checagem obrigatória: a falha torna a aplicação
+// indisponível.
+func (r *Registry) Register(name string, fn CheckFunc) {
+	r.add(check{name: name, fn: fn})
+}
+
+// RegisterOptional adiciona uma checagem informativa: a falha aparece no
+// relatório, mas não torna a aplicação indisponível.
+func (r *Registry) RegisterOptional(name string, fn CheckFunc) {
+	r.add(check{name: name, fn: fn, optional: true})
+}
+
+// add guarda a checagem.
+func (r *Registry) add(c check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, c)
+}
+
+// SetShuttingDown marca a aplicação como encerrando; a partir daí, Ready
+// falha sem executar as checagens.
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown indica se a aplicação está encerrando.
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Ready executa as checagens em paralelo, cada uma com o timeout do
+// registro, e retorna o relatório. O status é StatusFail se alguma checagem
+// obrigatória falhar ou se a aplicação estiver encerrando.
+func (r *Registry) Ready(ctx context.Context) Report {
+	if r.ShuttingDown() {
+		return Report{Status: StatusFail, Error: ErrShuttingDown.Error()}
+	}
+
+	r.mu.RLock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+
+	for i, c := range checks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = r.run(ctx, c)
+		}()
+	}
+
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+
+		if results[i].Status == StatusFail && !c.optional {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+// run executa uma checagem com o timeout do registro. Checagens que ignoram
+// o contexto são abandonadas quando o prazo expira.
+func (r *Registry) run(ctx context.Context, c check) CheckResult {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Optional:  c.optional,
+	}
+
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryReady(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	testCases := []struct {
+		name     string
+		register func(r *Registry)
+		status   string
+		checks   map[string]string
+	}{
+		{
+			name:     "no checks",
+			register: func(*Registry) {},
+			status:   StatusOK,
+			checks:   map[string]string{},
+		},
+		{
+			name: "all passing",
+			register: func(r *Registry) {
+				r.Register("database", func(context.Context) error { return nil })
+				r.RegisterOptional("redis", func(context.Context) error { return nil })
+			},
+			status: StatusOK,
+			checks: map[string]string{"database": StatusOK, "redis": StatusOK},
+		},
+		{
+			name: "required failing",
+			register: func(r *Registry) {
+				r.Register("database", func(context.Context) error { return errDown })
+				r.RegisterOptional("redis", func(context.Context) error { return nil })
+			},
+			status: StatusFail,
+			checks: map[string]string{"database": StatusFail, "redis": StatusOK},
+		},
+		{
+			name: "optional failing",
+			register: func(r *Registry) {
+				r.Register("database", func(context.Context) error { return nil })
+				r.RegisterOptional("redis", func(context.Context) error { return errDown })
+			},
+			status: StatusOK,
+			checks: map[string]string{"database": StatusOK, "redis": StatusFail},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry(time.Second)
+			tc.register(registry)
+
+			report := registry.Ready(context.Background())
+			assert.Equal(t, tc.status, report.Status)
+
+			statuses := make(map[string]string)
+			for name, result := range report.Checks {
+				statuses[name] = result.Status
+
+				if result.Status == StatusFail {
+					assert.Equal(t, errDown.Error(), result.Error)
+				}
+			}
+
+			assert.Equal(t, tc.checks, statuses)
+		})
+	}
+}
+
+func TestRegistryTimeout(t *testing.T) {
+	registry := NewRegistry(20 * time.Millisecond)
+
+	// Checagem que ignora o contexto
+	registry.Register("stuck", func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	report := registry.Ready(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["stuck"].Error)
+	assert.GreaterOrEqual(t, report.Checks["stuck"].LatencyMS, 20.0)
+}
+
+func TestRegistryShuttingDown(t *testing.T) {
+	called := false
+
+	registry := NewRegistry(time.Second)
+	registry.Register("database", func(context.Context) error {
+		called = true
+		return nil
+	})
+
+	registry.SetShuttingDown()
+
+	report := registry.Ready(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, ErrShuttingDown.Error(), report.Error)
+	assert.False(t, called)
+}
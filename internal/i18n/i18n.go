@@ -0,0 +1,79 @@
+// Package i18n fornece o catálogo de mensagens da API e a negociação de idioma.
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Idiomas suportados. O primeiro é o fallback.
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+	Spanish      = "es"
+)
+
+// Fallback é o idioma usado quando nenhum idioma suportado é solicitado.
+const Fallback = PortugueseBR
+
+// supported lista os idiomas na ordem usada pelo matcher; o primeiro é o fallback.
+var supported = []language.Tag{
+	language.BrazilianPortuguese,
+	language.English,
+	language.Spanish,
+}
+
+// matcher escolhe o idioma suportado mais próximo do solicitado.
+var matcher = language.NewMatcher(supported)
+
+// Negotiate escolhe o idioma da resposta. O parâmetro lang (p.ex. ?lang=en)
+// tem prioridade sobre o header Accept-Language; sem correspondência, o
+// resultado é o Fallback.
+func Negotiate(lang, acceptLanguage string) string {
+	var tags []language.Tag
+
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+
+	if acceptLanguage != "" {
+		if parsed, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+			tags = append(tags, parsed...)
+		}
+	}
+
+	if len(tags) == 0 {
+		return Fallback
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Fallback
+	}
+
+	return []string{PortugueseBR, English, Spanish}[index]
+}
+
+// T retorna a mensagem traduzida para o idioma, formatada com args.
+// Idiomas ou chaves sem tradução caem no Fallback; chaves desconhecidas
+// são retornadas como estão.
+func T(lang, key string, args ...interface{}) string {
+	translations, ok := messages[key]
+	if !ok {
+		return key
+	}
+
+	message, ok := translations[lang]
+	if !ok {
+		message = translations[Fallback]
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"empty", "", "", PortugueseBR},
+		{"portuguese", "", "pt-BR", PortugueseBR},
+		{"portuguese from portugal", "", "pt-PT", PortugueseBR},
+		{"english region", "", "en-GB", English},
+		{"spanish with quality", "", "de;q=0.9, es;q=0.8", Spanish},
+		{"query parameter", "es", "en", Spanish},
+		{"invalid query parameter", "???", "en", English},
+		{"unsupported", "", "ja", PortugueseBR},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Negotiate(tc.lang, tc.acceptLanguage))
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Dados inválidos", T(PortugueseBR, InvalidData))
+	assert.Equal(t, "Invalid data", T(English, InvalidData))
+	assert.Equal(t, "Dados inválidos", T("fr", InvalidData))
+	assert.Equal(t, "Dados inválidos", T("", InvalidData))
+	assert.Equal(t, "Parameter 'page' is required", T(English, MissingParameter, "page"))
+	assert.Equal(t, "unknown.key", T(English, "unknown.key"))
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for key, translations := range messages {
+		for _, lang := range []string{PortugueseBR, English, Spanish} {
+			assert.NotEmpty(t, translations[lang], "missing %s translation for %s", lang, key)
+		}
+	}
+}
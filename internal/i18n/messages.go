@@ -0,0 +1,296 @@
+package i18n
+
+// Chaves das mensagens do catálogo.
+const (
+	// Conversões de temperatura
+	SameUnitFormula     = "temperature.same_unit"
+	UnknownUnit         = "temperature.unknown_unit"
+	BelowAbsoluteZero   = "temperature.below_absolute_zero"
+	NonFiniteValue      = "temperature.non_finite_value"
+	InvalidPrecision    = "temperature.invalid_precision"
+	InvalidRoundingMode = "temperature.invalid_rounding_mode"
+	ConversionFailed    = "temperature.conversion_failed"
+	BatchTooLarge       = "temperature.batch_too_large"
+	HistoryFailed       = "temperature.history_failed"
+
+	// Requisições
+	InvalidData      = "request.invalid_data"
+	InvalidValue     = "request.invalid_value"
+	InvalidID        = "request.invalid_id"
+	MissingParameter = "request.missing_parameter"
+	InvalidParameter = "request.invalid_parameter"
+	InternalError    = "request.internal_error"
+	RouteNotFound    = "request.route_not_found"
+	MethodNotAllowed = "request.method_not_allowed"
+	RateLimited      = "request.rate_limited"
+
+	// Validação de campos
+	FieldRequired        = "field.required"
+	FieldEmail           = "field.email"
+	FieldMin             = "field.min"
+	FieldMax             = "field.max"
+	FieldOneOf           = "field.oneof"
+	FieldType            = "field.type"
+	FieldTemperatureUnit = "field.temperature_unit"
+	FieldInvalid         = "field.invalid"
+
+	// Usuários
+	InvalidEmail       = "user.invalid_email"
+	WeakPassword       = "user.weak_password"
+	UserNotFound       = "user.not_found"
+	EmailAlreadyExists = "user.email_already_exists"
+	UserFailed         = "user.failed"
+
+	// Autenticação
+	MissingAccessToken  = "auth.missing_access_token"
+	InvalidAccessToken  = "auth.invalid_access_token"
+	InvalidCredentials  = "auth.invalid_credentials"
+	InvalidRefreshToken = "auth.invalid_refresh_token"
+	InactiveUser        = "auth.inactive_user"
+	NotAuthenticated    = "auth.not_authenticated"
+	Unauthenticated     = "auth.unauthenticated"
+	PermissionRequired  = "auth.permission_required"
+	AuthFailed          = "auth.failed"
+	InvalidAPIKey       = "auth.invalid_api_key"
+
+	// API keys e papéis
+	APIKeyNotFound     = "api_key.not_found"
+	APIKeyScopeNotHeld = "api_key.scope_not_held"
+	APIKeyFailed       = "api_key.failed"
+	RoleNotFound       = "role.not_found"
+	RoleFailed         = "role.failed"
+)
+
+// messages mapeia cada chave às traduções por idioma.
+var messages = map[string]map[string]string{
+	SameUnitFormula: {
+		PortugueseBR: "Mesma unidade, sem conversão necessária",
+		English:      "Same unit, no conversion needed",
+		Spanish:      "Misma unidad, no se necesita conversión",
+	},
+	UnknownUnit: {
+		PortugueseBR: "Unidade desconhecida",
+		English:      "Unknown unit",
+		Spanish:      "Unidad desconocida",
+	},
+	BelowAbsoluteZero: {
+		PortugueseBR: "Temperatura abaixo do zero absoluto",
+		English:      "Temperature below absolute zero",
+		Spanish:      "Temperatura por debajo del cero absoluto",
+	},
+	NonFiniteValue: {
+		PortugueseBR: "Valor deve ser um número finito",
+		English:      "Value must be a finite number",
+		Spanish:      "El valor debe ser un número finito",
+	},
+	InvalidPrecision: {
+		PortugueseBR: "Precisão inválida",
+		English:      "Invalid precision",
+		Spanish:      "Precisión inválida",
+	},
+	InvalidRoundingMode: {
+		PortugueseBR: "Modo de arredondamento inválido",
+		English:      "Invalid rounding mode",
+		Spanish:      "Modo de redondeo inválido",
+	},
+	ConversionFailed: {
+		PortugueseBR: "Erro ao converter temperatura",
+		English:      "Failed to convert temperature",
+		Spanish:      "Error al convertir la temperatura",
+	},
+	BatchTooLarge: {
+		PortugueseBR: "Lote excede o tamanho máximo de %d itens",
+		English:      "Batch exceeds the maximum size of %d items",
+		Spanish:      "El lote excede el tamaño máximo de %d elementos",
+	},
+	HistoryFailed: {
+		PortugueseBR: "Erro ao consultar o histórico de conversões",
+		English:      "Failed to query the conversion history",
+		Spanish:      "Error al consultar el historial de conversiones",
+	},
+	InvalidData: {
+		PortugueseBR: "Dados inválidos",
+		English:      "Invalid data",
+		Spanish:      "Datos inválidos",
+	},
+	InvalidValue: {
+		PortugueseBR: "Valor inválido",
+		English:      "Invalid value",
+		Spanish:      "Valor inválido",
+	},
+	InvalidID: {
+		PortugueseBR: "ID inválido",
+		English:      "Invalid ID",
+		Spanish:      "ID inválido",
+	},
+	MissingParameter: {
+		PortugueseBR: "Parâmetro '%s' é obrigatório",
+		English:      "Parameter '%s' is required",
+		Spanish:      "El parámetro '%s' es obligatorio",
+	},
+	InvalidParameter: {
+		PortugueseBR: "Parâmetro '%s' inválido",
+		English:      "Invalid parameter '%s'",
+		Spanish:      "Parámetro '%s' inválido",
+	},
+	InternalError: {
+		PortugueseBR: "Erro interno do servidor",
+		English:      "Internal server error",
+		Spanish:      "Error interno del servidor",
+	},
+	RouteNotFound: {
+		PortugueseBR: "Rota não encontrada",
+		English:      "Route not found",
+		Spanish:      "Ruta no encontrada",
+	},
+	MethodNotAllowed: {
+		PortugueseBR: "Método não permitido para esta rota",
+		English:      "Method not allowed for this route",
+		Spanish:      "Método no permitido para esta ruta",
+	},
+	RateLimited: {
+		PortugueseBR: "Limite de requisições excedido, tente novamente mais tarde",
+		English:      "Rate limit exceeded, try again later",
+		Spanish:      "Límite de solicitudes excedido, inténtelo de nuevo más tarde",
+	},
+	FieldRequired: {
+		PortugueseBR: "Campo obrigatório",
+		English:      "Field is required",
+		Spanish:      "Campo obligatorio",
+	},
+	FieldEmail: {
+		PortugueseBR: "Deve ser um email válido",
+		English:      "Must be a valid email",
+		Spanish:      "Debe ser un correo electrónico válido",
+	},
+	FieldMin: {
+		PortugueseBR: "Deve ter no mínimo %s",
+		English:      "Must be at least %s",
+		Spanish:      "Debe tener como mínimo %s",
+	},
+	FieldMax: {
+		PortugueseBR: "Deve ter no máximo %s",
+		English:      "Must be at most %s",
+		Spanish:      "Debe tener como máximo %s",
+	},
+	FieldOneOf: {
+		PortugueseBR: "Deve ser um dos valores: %s",
+		English:      "Must be one of: %s",
+		Spanish:      "Debe ser uno de los valores: %s",
+	},
+	FieldType: {
+		PortugueseBR: "Deve ser do tipo %s",
+		English:      "Must be of type %s",
+		Spanish:      "Debe ser de tipo %s",
+	},
+	FieldTemperatureUnit: {
+		PortugueseBR: "Unidade de temperatura desconhecida",
+		English:      "Unknown temperature unit",
+		Spanish:      "Unidad de temperatura desconocida",
+	},
+	FieldInvalid: {
+		PortugueseBR: "Valor inválido",
+		English:      "Invalid value",
+		Spanish:      "Valor inválido",
+	},
+	InvalidEmail: {
+		PortugueseBR: "Email inválido",
+		English:      "Invalid email",
+		Spanish:      "Correo electrónico inválido",
+	},
+	WeakPassword: {
+		PortugueseBR: "Senha deve ter no mínimo 8 caracteres, com letras maiúsculas, minúsculas e números",
+		English:      "Password must be at least 8 characters long, with uppercase and lowercase letters and numbers",
+		Spanish:      "La contraseña debe tener al menos 8 caracteres, con mayúsculas, minúsculas y números",
+	},
+	UserNotFound: {
+		PortugueseBR: "Usuário não encontrado",
+		English:      "User not found",
+		Spanish:      "Usuario no encontrado",
+	},
+	EmailAlreadyExists: {
+		PortugueseBR: "Email já cadastrado",
+		English:      "Email already registered",
+		Spanish:      "Correo electrónico ya registrado",
+	},
+	UserFailed: {
+		PortugueseBR: "Erro ao processar usuário",
+		English:      "Failed to process user",
+		Spanish:      "Error al procesar el usuario",
+	},
+	MissingAccessToken: {
+		PortugueseBR: "Token de acesso ausente",
+		English:      "Missing access token",
+		Spanish:      "Falta el token de acceso",
+	},
+	InvalidAccessToken: {
+		PortugueseBR: "Token de acesso inválido ou expirado",
+		English:      "Invalid or expired access token",
+		Spanish:      "Token de acceso inválido o expirado",
+	},
+	InvalidCredentials: {
+		PortugueseBR: "Email ou senha inválidos",
+		English:      "Invalid email or password",
+		Spanish:      "Correo electrónico o contraseña inválidos",
+	},
+	InvalidRefreshToken: {
+		PortugueseBR: "Refresh token inválido ou expirado",
+		English:      "Invalid or expired refresh token",
+		Spanish:      "Refresh token inválido o expirado",
+	},
+	InactiveUser: {
+		PortugueseBR: "Usuário inativo",
+		English:      "Inactive user",
+		Spanish:      "Usuario inactivo",
+	},
+	NotAuthenticated: {
+		PortugueseBR: "Autenticação necessária",
+		English:      "Authentication required",
+		Spanish:      "Autenticación requerida",
+	},
+	Unauthenticated: {
+		PortugueseBR: "Não autenticado",
+		English:      "Not authenticated",
+		Spanish:      "No autenticado",
+	},
+	PermissionRequired: {
+		PortugueseBR: "Permissão necessária: %s",
+		English:      "Permission required: %s",
+		Spanish:      "Permiso requerido: %s",
+	},
+	AuthFailed: {
+		PortugueseBR: "Erro ao autenticar",
+		English:      "Authentication failed",
+		Spanish:      "Error al autenticar",
+	},
+	InvalidAPIKey: {
+		PortugueseBR: "API key inválida, expirada ou revogada",
+		English:      "Invalid, expired or revoked API key",
+		Spanish:      "API key inválida, expirada o revocada",
+	},
+	APIKeyNotFound: {
+		PortugueseBR: "API key não encontrada",
+		English:      "API key not found",
+		Spanish:      "API key no encontrada",
+	},
+	APIKeyScopeNotHeld: {
+		PortugueseBR: "Uma API key só pode receber escopos que o seu criador possui",
+		English:      "An API key can only be granted scopes its creator holds",
+		Spanish:      "Una API key solo puede recibir ámbitos que su creador posee",
+	},
+	APIKeyFailed: {
+		PortugueseBR: "Erro ao processar API key",
+		English:      "Failed to process API key",
+		Spanish:      "Error al procesar la API key",
+	},
+	RoleNotFound: {
+		PortugueseBR: "Papel não encontrado",
+		English:      "Role not found",
+		Spanish:      "Rol no encontrado",
+	},
+	RoleFailed: {
+		PortugueseBR: "Erro ao processar papel",
+		English:      "Failed to process role",
+		Spanish:      "Error al procesar el rol",
+	},
+}
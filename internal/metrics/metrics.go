@@ -0,0 +1,90 @@
+// Package metrics expõe as métricas da aplicação no formato do Prometheus.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace é o prefixo das métricas da aplicação.
+const namespace = "golang_api"
+
+// unmatchedRoute é o rótulo das requisições que não casam com nenhuma rota,
+// evitando uma série por URL desconhecida.
+const unmatchedRoute = "unmatched"
+
+// Metrics reúne os coletores da aplicação em um registry próprio.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	conversions     *prometheus.CounterVec
+}
+
+// New cria as métricas HTTP e de conversões, além das métricas do runtime
+// do Go e do processo.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Total de requisições HTTP, por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duração das requisições HTTP, por método, rota e status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		conversions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "temperature_conversions_total",
+			Help:      "Total de conversões de temperatura, por unidade de origem e de destino.",
+		}, []string{"from_unit", "to_unit"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.conversions,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// RegisterDB adiciona as estatísticas do pool de conexões do banco.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name)) //nolint:wrapcheck
+}
+
+// ObserveRequest registra uma requisição HTTP. route é o template da rota
+// (p.ex. "/api/v1/users/:id"); vazio indica que nenhuma rota casou.
+func (m *Metrics) ObserveRequest(method, route string, status int, latency time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+
+	m.requests.With(labels).Inc()
+	m.requestDuration.With(labels).Observe(latency.Seconds())
+}
+
+// ObserveConversion registra uma conversão de temperatura.
+func (m *Metrics) ObserveConversion(fromUnit, toUnit string) {
+	m.conversions.WithLabelValues(fromUnit, toUnit).Inc()
+}
+
+// Handler retorna o handler HTTP que expõe as métricas.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+
+	m.ObserveRequest(http.MethodGet, "/api/v1/users/:id", http.StatusOK, 30*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/api/v1/users/:id", http.StatusOK, 10*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	m.ObserveConversion("celsius", "kelvin")
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	body := w.Body.String()
+	assert.Contains(t, body, `golang_api_http_requests_total{method="GET",route="/api/v1/users/:id",status="200"} 2`)
+	assert.Contains(t, body, `golang_api_http_request_duration_seconds_sum{method="GET",route="/api/v1/users/:id",status="200"} 0.04`)
+	assert.Contains(t, body, `golang_api_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `golang_api_temperature_conversions_total{from_unit="celsius",to_unit="kelvin"} 1`)
+	assert.Contains(t, body, "go_memstats_alloc_bytes")
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+	"golang/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyContextKey é a chave da API key autenticada no gin.Context.
+const apiKeyContextKey = "auth_api_key"
+
+// APIKeyAuthenticator valida chaves de API.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// APIKeyAuthMiddleware autentica clientes máquina pelos headers
+// "Authorization: ApiKey <chave>" ou "X-API-Key: <chave>". Requisições sem
+// chave seguem adiante; chaves inválidas são rejeitadas com 401.
+func APIKeyAuthMiddleware(keys APIKeyAuthenticator) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		key, ok := apiKeyFromRequest(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		apiKey, err := keys.AuthenticateAPIKey(c.Request.Context(), key)
+		if err != nil {
+			c.Header("WWW-Authenticate", `ApiKey realm="api"`)
+			AbortWithError(c, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidAPIKey, i18n.InvalidAPIKey).Wrap(err))
+
+			return
+		}
+
+		c.Set(apiKeyContextKey, apiKey)
+		c.Next()
+	})
+}
+
+// RequireAuth aceita requisições já autenticadas por API key ou por um
+// middleware JWT anterior; caso contrário, exige um access token JWT válido.
+func RequireAuth(jwtAuth gin.HandlerFunc) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if _, ok := CurrentAPIKey(c); ok {
+			c.Next()
+			return
+		}
+
+		if _, ok := CurrentUser(c); ok {
+			c.Next()
+			return
+		}
+
+		jwtAuth(c)
+	})
+}
+
+// CurrentAPIKey retorna a API key autenticada, se houver.
+func CurrentAPIKey(c *gin.Context) (*models.APIKey, bool) {
+	value, exists := c.Get(apiKeyContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	apiKey, ok := value.(*models.APIKey)
+
+	return apiKey, ok
+}
+
+// apiKeyFromRequest extrai a chave dos headers suportados.
+func apiKeyFromRequest(c *gin.Context) (string, bool) {
+	const prefix = "ApiKey "
+
+	header := c.GetHeader("Authorization")
+	if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+		return strings.TrimSpace(header[len(prefix):]), true
+	}
+
+	if key := strings.TrimSpace(c.GetHeader("X-API-Key")); key != "" {
+		return key, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang/internal/apierror"
+	"golang/internal/auth"
+	"golang/internal/database"
+	"golang/internal/i18n"
+	"golang/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userContextKey é a chave do usuário autenticado no gin.Context.
+const userContextKey = "auth_user"
+
+// UserLoader carrega o usuário autenticado, com papéis e permissões,
+// a partir do ID do token.
+type UserLoader interface {
+	GetUserWithPermissions(ctx context.Context, id uint) (*models.User, error)
+}
+
+// JWTAuthMiddleware exige um access token válido no header
+// "Authorization: Bearer <token>" e coloca o usuário no contexto.
+func JWTAuthMiddleware(tokens *auth.TokenManager, users UserLoader) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		tokenString, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, apierror.CodeUnauthorized, i18n.MissingAccessToken)
+			return
+		}
+
+		if authenticateJWT(c, tokens, users, tokenString) {
+			c.Next()
+		}
+	})
+}
+
+// OptionalJWTAuthMiddleware identifica o usuário quando há um access token,
+// sem exigi-lo. Tokens presentes mas inválidos são rejeitados.
+func OptionalJWTAuthMiddleware(tokens *auth.TokenManager, users UserLoader) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		tokenString, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if authenticateJWT(c, tokens, users, tokenString) {
+			c.Next()
+		}
+	})
+}
+
+// authenticateJWT valida o token e coloca o usuário no contexto, abortando
+// a requisição e retornando false se ele for inválido.
+func authenticateJWT(c *gin.Context, tokens *auth.TokenManager, users UserLoader, tokenString string) bool {
+	claims, err := tokens.ParseAccessToken(tokenString)
+	if err != nil {
+		abortUnauthorized(c, apierror.CodeInvalidToken, i18n.InvalidAccessToken)
+		return false
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		abortUnauthorized(c, apierror.CodeInvalidToken, i18n.InvalidAccessToken)
+		return false
+	}
+
+	// No primário, para que desativações e mudanças de papel valham de imediato
+	user, err := users.GetUserWithPermissions(database.WithPrimary(c.Request.Context()), userID)
+	if err != nil {
+		abortUnauthorized(c, apierror.CodeInvalidToken, i18n.UserNotFound)
+		return false
+	}
+
+	if !user.Active {
+		abortForbidden(c, apierror.CodeInactiveUser, i18n.InactiveUser)
+		return false
+	}
+
+	SetCurrentUser(c, user)
+
+	return true
+}
+
+// SetCurrentUser coloca o usuário autenticado no contexto.
+func SetCurrentUser(c *gin.Context, user *models.User) {
+	c.Set(userContextKey, user)
+}
+
+// CurrentUser retorna o usuário autenticado, se houver.
+func CurrentUser(c *gin.Context) (*models.User, bool) {
+	value, exists := c.Get(userContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	user, ok := value.(*models.User)
+
+	return user, ok
+}
+
+// bearerToken extrai o token de um header "Bearer <token>".
+func bearerToken(header string) (string, bool) {
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(header[len(prefix):]), true
+}
+
+// RequirePermission exige que o usuário autenticado tenha a permissão por
+// meio de algum de seus papéis, ou que a API key autenticada tenha o escopo
+// correspondente. Usuários inativos são sempre rejeitados.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if apiKey, ok := CurrentAPIKey(c); ok {
+			if !apiKey.Scopes.Contains(permission) {
+				abortForbidden(c, apierror.CodeForbidden, i18n.PermissionRequired, permission)
+				return
+			}
+
+			c.Next()
+
+			return
+		}
+
+		user, ok := CurrentUser(c)
+		if !ok {
+			abortUnauthorized(c, apierror.CodeUnauthorized, i18n.NotAuthenticated)
+			return
+		}
+
+		if !user.Active {
+			abortForbidden(c, apierror.CodeInactiveUser, i18n.InactiveUser)
+			return
+		}
+
+		if !user.HasPermission(permission) {
+			abortForbidden(c, apierror.CodeForbidden, i18n.PermissionRequired, permission)
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// abortForbidden encerra a requisição com 403.
+func abortForbidden(c *gin.Context, code apierror.Code, message string, args ...interface{}) {
+	AbortWithError(c, apierror.New(http.StatusForbidden, code, message, args...))
+}
+
+// abortUnauthorized encerra a requisição com 401.
+func abortUnauthorized(c *gin.Context, code apierror.Code, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	AbortWithError(c, apierror.New(http.StatusUnauthorized, code, message))
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPermissionRouter cria um router com o principal informado no contexto
+func newPermissionRouter(user *models.User, apiKey *models.APIKey, permission string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		if user != nil {
+			SetCurrentUser(c, user)
+		}
+
+		if apiKey != nil {
+			c.Set(apiKeyContextKey, apiKey)
+		}
+	}, RequirePermission(permission), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+func TestRequirePermission(t *testing.T) {
+	admin := models.Role{Name: models.RoleAdmin, Permissions: []models.Permission{{Name: models.PermissionAll}}}
+	reader := models.Role{Name: "reader", Permissions: []models.Permission{{Name: models.PermissionUsersRead}}}
+
+	testCases := []struct {
+		name     string
+		user     *models.User
+		apiKey   *models.APIKey
+		expected int
+	}{
+		{"unauthenticated", nil, nil, http.StatusUnauthorized},
+		{"user without role", &models.User{Active: true}, nil, http.StatusForbidden},
+		{"user with permission", &models.User{Active: true, Roles: []models.Role{reader}}, nil, http.StatusOK},
+		{"admin wildcard", &models.User{Active: true, Roles: []models.Role{admin}}, nil, http.StatusOK},
+		{"inactive admin", &models.User{Active: false, Roles: []models.Role{admin}}, nil, http.StatusForbidden},
+		{"api key with scope", nil, &models.APIKey{Scopes: models.Scopes{models.PermissionUsersRead}}, http.StatusOK},
+		{"api key without scope", nil, &models.APIKey{Scopes: models.Scopes{"other"}}, http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newPermissionRouter(tc.user, tc.apiKey, models.PermissionUsersRead)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
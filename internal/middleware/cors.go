@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// originMatcher casa uma origem exata, "*" ou um padrão com um curinga de
+// subdomínio, como "https://*.example.com".
+type originMatcher struct {
+	any    bool
+	exact  string
+	prefix string
+	suffix string
+}
+
+// newOriginMatcher interpreta um item de CORS_ALLOWED_ORIGINS.
+func newOriginMatcher(pattern string) originMatcher {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "/"))
+	if pattern == "*" {
+		return originMatcher{any: true}
+	}
+
+	if prefix, suffix, found := strings.Cut(pattern, "*"); found {
+		return originMatcher{prefix: prefix, suffix: suffix}
+	}
+
+	return originMatcher{exact: pattern}
+}
+
+// match indica se a origem (já em minúsculas) é permitida. O curinga cobre
+// um ou mais rótulos de subdomínio, mas nunca o domínio raiz.
+func (m originMatcher) match(origin string) bool {
+	switch {
+	case m.any:
+		return true
+	case m.exact != "":
+		return origin == m.exact
+	}
+
+	if len(origin) <= len(m.prefix)+len(m.suffix) ||
+		!strings.HasPrefix(origin, m.prefix) || !strings.HasSuffix(origin, m.suffix) {
+		return false
+	}
+
+	subdomain := origin[len(m.prefix) : len(origin)-len(m.suffix)]
+
+	return !strings.ContainsAny(subdomain, "/:@?#")
+}
+
+// CORSMiddleware aplica a política de CORS configurada. A origem permitida é
+// devolvida em Access-Control-Allow-Origin (nunca "*"), e preflights de
+// origens não permitidas são recusados com 403.
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	matchers := make([]originMatcher, 0, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		matchers = append(matchers, newOriginMatcher(origin))
+	}
+
+	allowMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(cfg.MaxAge)
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		// A resposta varia conforme a origem, mesmo quando ela não é permitida
+		c.Writer.Header().Add("Vary", "Origin")
+
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		if !originAllowed(matchers, strings.ToLower(origin)) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+
+			c.Next()
+
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposeHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", exposeHeaders)
+			}
+
+			c.Next()
+
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		c.Header("Access-Control-Allow-Methods", allowMethods)
+		c.Header("Access-Control-Allow-Headers", allowHeaders)
+
+		if cfg.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+
+		c.AbortWithStatus(http.StatusNoContent)
+	})
+}
+
+// originAllowed indica se algum padrão configurado aceita a origem.
+func originAllowed(matchers []originMatcher, origin string) bool {
+	for _, matcher := range matchers {
+		if matcher.match(origin) {
+			return true
+		}
+	}
+
+	return false
+}
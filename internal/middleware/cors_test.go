@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCORSRouter cria um router com o CORSMiddleware e uma rota GET /
+func newCORSRouter(cfg config.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(CORSMiddleware(cfg))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	cfg := config.CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		MaxAge:           600,
+		AllowCredentials: true,
+	}
+
+	testCases := []struct {
+		name    string
+		origin  string
+		allowed bool
+	}{
+		{name: "exact", origin: "https://app.example.com", allowed: true},
+		{name: "exact case insensitive", origin: "https://APP.example.com", allowed: true},
+		{name: "other scheme", origin: "http://app.example.com"},
+		{name: "wildcard subdomain", origin: "https://api.example.org", allowed: true},
+		{name: "wildcard nested subdomain", origin: "https://a.b.example.org", allowed: true},
+		{name: "wildcard root domain", origin: "https://example.org"},
+		{name: "wildcard suffix attack", origin: "https://evil.com/.example.org"},
+		{name: "unknown", origin: "https://evil.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newCORSRouter(cfg)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set("Origin", tc.origin)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Values("Vary"), "Origin")
+
+			if !tc.allowed {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+
+			assert.Equal(t, tc.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, "X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+		})
+	}
+}
+
+func TestCORSMiddlewarePreflight(t *testing.T) {
+	router := newCORSRouter(config.CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         300,
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/", http.NoBody)
+	req.Header.Set("Origin", "https://any.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://any.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "300", w.Header().Get("Access-Control-Max-Age"))
+
+	t.Run("origin not allowed", func(t *testing.T) {
+		router := newCORSRouter(config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodOptions, "/", http.NoBody)
+		req.Header.Set("Origin", "https://evil.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// ProblemContentType é o media type das respostas de erro (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix é o prefixo do URI que identifica cada código de erro.
+const problemTypePrefix = "/errors/"
+
+// Problem é o corpo das respostas de erro no formato RFC 7807, com os
+// membros de extensão code, request_id e errors.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	Code      apierror.Code  `json:"code"`
+	RequestID string         `json:"request_id,omitempty"`
+	Errors    []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField descreve a falha de validação de um campo.
+type ProblemField struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// fieldMessages mapeia as regras do validator às mensagens do catálogo.
+// As mensagens das regras em fieldMessagesWithParam recebem o parâmetro
+// da regra (p.ex. o "8" de "min=8").
+var (
+	fieldMessages = map[string]string{
+		"required":         i18n.FieldRequired,
+		"email":            i18n.FieldEmail,
+		"password":         i18n.WeakPassword,
+		"temperature_unit": i18n.FieldTemperatureUnit,
+	}
+	fieldMessagesWithParam = map[string]string{
+		"min":   i18n.FieldMin,
+		"max":   i18n.FieldMax,
+		"oneof": i18n.FieldOneOf,
+		"type":  i18n.FieldType,
+	}
+)
+
+// ErrorHandlerMiddleware converte o último erro registrado com AbortWithError
+// em uma resposta application/problem+json. Erros que não são
+// *apierror.Error viram 500, e erros 5xx são registrados no log com a causa.
+func ErrorHandlerMiddleware(logger *Logger) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		apiErr := toAPIError(c.Errors.Last().Err)
+
+		if apiErr.Status >= http.StatusInternalServerError {
+			logger.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+				"error":  apiErr.Error(),
+				"method": c.Request.Method,
+				"path":   c.Request.URL.Path,
+			}).Error("Request failed")
+		}
+
+		if c.Writer.Written() {
+			return
+		}
+
+		writeProblem(c, apiErr)
+	})
+}
+
+// AbortWithError encerra a requisição com o status do erro, registrando-o
+// para que o ErrorHandlerMiddleware escreva o corpo da resposta.
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Status(toAPIError(err).Status)
+	c.Abort()
+}
+
+// NewProblem monta o corpo RFC 7807 do erro no idioma da requisição.
+func NewProblem(c *gin.Context, apiErr *apierror.Error) Problem {
+	problem := Problem{
+		Type:      problemTypePrefix + string(apiErr.Code),
+		Title:     T(c, apiErr.Message, apiErr.Args...),
+		Status:    apiErr.Status,
+		Detail:    apiErr.Detail,
+		Code:      apiErr.Code,
+		RequestID: requestID(c),
+	}
+
+	if c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+
+	for _, field := range apiErr.Fields {
+		problem.Errors = append(problem.Errors, ProblemField{
+			Field:   field.Field,
+			Rule:    field.Tag,
+			Message: fieldMessage(c, field),
+		})
+	}
+
+	return problem
+}
+
+// fieldMessage traduz a falha de validação de um campo.
+func fieldMessage(c *gin.Context, field apierror.FieldError) string {
+	if key, ok := fieldMessagesWithParam[field.Tag]; ok {
+		return T(c, key, field.Param)
+	}
+
+	if key, ok := fieldMessages[field.Tag]; ok {
+		return T(c, key)
+	}
+
+	return T(c, i18n.FieldInvalid)
+}
+
+// writeProblem escreve a resposta de erro e encerra a requisição.
+func writeProblem(c *gin.Context, apiErr *apierror.Error) {
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(apiErr.Status, NewProblem(c, apiErr))
+}
+
+// toAPIError retorna o *apierror.Error da cadeia ou um erro interno genérico.
+func toAPIError(err error) *apierror.Error {
+	var apiErr *apierror.Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	return apierror.Internal(i18n.InternalError, err)
+}
+
+// requestID retorna o ID da requisição definido pelo RequestIDMiddleware.
+func requestID(c *gin.Context) string {
+	if c.Request == nil {
+		return ""
+	}
+
+	return RequestIDFromContext(c.Request.Context())
+}
+
+// recoveredError descreve um pânico recuperado.
+func recoveredError(recovered interface{}) error {
+	return fmt.Errorf("panic: %v", recovered) //nolint:err113
+}
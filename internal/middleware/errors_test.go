@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newErrorRouter cria um router com os middlewares de erro e o handler informado
+func newErrorRouter(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	logger := NewLogger()
+	logger.SetOutput(httptest.NewRecorder())
+
+	router := gin.New()
+	router.Use(RecoveryMiddleware(logger), ErrorHandlerMiddleware(logger), LanguageMiddleware())
+	router.GET("/", handler)
+
+	return router
+}
+
+func TestErrorHandlerMiddleware(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  gin.HandlerFunc
+		status   int
+		code     apierror.Code
+		title    string
+		detail   string
+		language string
+	}{
+		{
+			name: "api error",
+			handler: func(c *gin.Context) {
+				AbortWithError(c, apierror.NotFound(i18n.UserNotFound).WithDetail("id 7"))
+			},
+			status: http.StatusNotFound,
+			code:   apierror.CodeNotFound,
+			title:  "Usuário não encontrado",
+			detail: "id 7",
+		},
+		{
+			name: "translated",
+			handler: func(c *gin.Context) {
+				AbortWithError(c, apierror.BadRequest(i18n.InvalidParameter, "page"))
+			},
+			status:   http.StatusBadRequest,
+			code:     apierror.CodeInvalidRequest,
+			title:    "Invalid parameter 'page'",
+			language: "en",
+		},
+		{
+			name: "unknown error hides cause",
+			handler: func(c *gin.Context) {
+				AbortWithError(c, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError,
+			code:   apierror.CodeInternal,
+			title:  "Erro interno do servidor",
+		},
+		{
+			name: "panic",
+			handler: func(c *gin.Context) {
+				panic("boom")
+			},
+			status: http.StatusInternalServerError,
+			code:   apierror.CodeInternal,
+			title:  "Erro interno do servidor",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newErrorRouter(tc.handler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tc.language != "" {
+				req.Header.Set("Accept-Language", tc.language)
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			assert.NotContains(t, w.Body.String(), "connection refused")
+			assert.NotContains(t, w.Body.String(), "boom")
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, tc.code, problem.Code)
+			assert.Equal(t, tc.title, problem.Title)
+			assert.Equal(t, tc.detail, problem.Detail)
+		})
+	}
+}
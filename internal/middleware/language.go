@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"golang/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// languageContextKey é a chave do idioma negociado no gin.Context.
+const languageContextKey = "lang"
+
+// LanguageMiddleware negocia o idioma da resposta pelo parâmetro "lang" ou,
+// na ausência dele, pelo header Accept-Language, e o informa em
+// Content-Language.
+func LanguageMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		lang := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+		c.Set(languageContextKey, lang)
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	})
+}
+
+// Lang retorna o idioma negociado para a requisição, ou o fallback.
+func Lang(c *gin.Context) string {
+	if lang := c.GetString(languageContextKey); lang != "" {
+		return lang
+	}
+
+	return i18n.Fallback
+}
+
+// T traduz a mensagem para o idioma da requisição.
+func T(c *gin.Context, key string, args ...interface{}) string {
+	return i18n.T(Lang(c), key, args...)
+}
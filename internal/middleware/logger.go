@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Logger é um wrapper para o logrus.
@@ -11,8 +12,14 @@ type Logger struct {
 	*logrus.Logger
 }
 
-// NewLogger cria uma nova instância do logger.
+// NewLogger cria uma nova instância do logger no nível info.
 func NewLogger() *Logger {
+	return NewLoggerWithLevel("info")
+}
+
+// NewLoggerWithLevel cria uma nova instância do logger no nível informado
+// (debug, info, warn ou error). Níveis desconhecidos usam info.
+func NewLoggerWithLevel(level string) *Logger {
 	log := logrus.New()
 
 	// Configurar formato de saída
@@ -20,8 +27,7 @@ func NewLogger() *Logger {
 		TimestampFormat: "2006-01-02 15:04:05",
 	})
 
-	// Configurar nível de log baseado na variável de ambiente
-	level := os.Getenv("LOG_LEVEL")
+	// Configurar nível de log
 	switch level {
 	case "debug":
 		log.SetLevel(logrus.DebugLevel)
@@ -38,6 +44,9 @@ func NewLogger() *Logger {
 	// Configurar saída
 	log.SetOutput(os.Stdout)
 
+	// Incluir o ID da requisição e o trace nos logs feitos com WithContext
+	log.AddHook(contextHook{})
+
 	return &Logger{log}
 }
 
@@ -55,3 +64,30 @@ func (l *Logger) WithFields(fields logrus.Fields) *logrus.Entry {
 func (l *Logger) Writer() *logrus.Logger {
 	return l.Logger
 }
+
+// contextHook adiciona às entradas os campos request_id, trace_id e span_id
+// a partir do contexto, quando presentes.
+type contextHook struct{}
+
+// Levels aplica o hook a todos os níveis.
+func (contextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adiciona os campos do contexto à entrada.
+func (contextHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	if id := RequestIDFromContext(entry.Context); id != "" {
+		entry.Data["request_id"] = id
+	}
+
+	if spanContext := trace.SpanContextFromContext(entry.Context); spanContext.IsValid() {
+		entry.Data["trace_id"] = spanContext.TraceID().String()
+		entry.Data["span_id"] = spanContext.SpanID().String()
+	}
+
+	return nil
+}
@@ -1,34 +1,24 @@
 package middleware
 
 import (
-	"net/http"
 	"time"
 
+	"golang/internal/apierror"
+	"golang/internal/i18n"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
-// CORSMiddleware configura CORS.
-func CORSMiddleware() gin.HandlerFunc {
-	return gin.HandlerFunc(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers",
-			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, "+
-				"Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
-			return
-		}
-
-		c.Next()
-	})
+// RequestObserver recebe o resultado de cada requisição, como as métricas
+// HTTP. route é o template da rota, vazio se nenhuma rota casou.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, latency time.Duration)
 }
 
-// LoggingMiddleware registra informações sobre as requisições.
-func LoggingMiddleware(logger *Logger) gin.HandlerFunc {
+// LoggingMiddleware registra informações sobre as requisições e as repassa
+// aos observers.
+func LoggingMiddleware(logger *Logger, observers ...RequestObserver) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -41,7 +31,7 @@ func LoggingMiddleware(logger *Logger) gin.HandlerFunc {
 		latency := time.Since(start)
 
 		// Logar informações da requisição
-		logger.WithFields(logrus.Fields{
+		logger.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 			"status":     c.Writer.Status(),
 			"method":     c.Request.Method,
 			"path":       path,
@@ -50,10 +40,17 @@ func LoggingMiddleware(logger *Logger) gin.HandlerFunc {
 			"user_agent": c.Request.UserAgent(),
 			"latency":    latency,
 		}).Info("HTTP Request")
+
+		for _, observer := range observers {
+			observer.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), latency)
+		}
 	})
 }
 
-// RecoveryMiddleware recupera de pânicos.
+// RecoveryMiddleware recupera de pânicos, registrando o stack trace no log
+// e respondendo 500 no formato application/problem+json.
 func RecoveryMiddleware(logger *Logger) gin.HandlerFunc {
-	return gin.RecoveryWithWriter(logger.Out)
+	return gin.CustomRecoveryWithWriter(logger.Out, func(c *gin.Context, recovered interface{}) {
+		writeProblem(c, apierror.Internal(i18n.InternalError, recoveredError(recovered)))
+	})
 }
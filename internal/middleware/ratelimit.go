@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang/internal/apierror"
+	"golang/internal/config"
+	"golang/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitSweepInterval é o intervalo entre as limpezas dos buckets cheios
+// do RateLimitStore em memória.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitResult é o estado do bucket após consumir uma requisição.
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset é o tempo até o bucket voltar a ficar cheio
+	Reset time.Duration
+	// RetryAfter é o tempo até a próxima requisição ser aceita (só quando negada)
+	RetryAfter time.Duration
+}
+
+// RateLimitStore guarda os token buckets. Take consome um token do bucket da
+// chave, criando-o cheio se ainda não existir.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit config.RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitMiddleware limita as requisições do grupo de rotas com um token
+// bucket por cliente, identificado pela API key, pelo usuário autenticado ou
+// pelo IP, nessa ordem. Deve ser registrado após os middlewares de
+// autenticação do grupo. Falhas do store não bloqueiam a requisição.
+func RateLimitMiddleware(store RateLimitStore, logger *Logger, group string, limit config.RateLimit) gin.HandlerFunc {
+	if limit.Requests <= 0 || limit.Period <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	if limit.Burst <= 0 {
+		limit.Burst = limit.Requests
+	}
+
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, limit.Period)
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		result, err := store.Take(c.Request.Context(), group+":"+rateLimitClient(c), limit)
+		if err != nil {
+			logger.WithContext(c.Request.Context()).WithField("error", err).Warn("Rate limit store failed")
+			c.Next()
+
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			AbortWithError(c, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, i18n.RateLimited))
+
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// rateLimitClient identifica o cliente da requisição.
+func rateLimitClient(c *gin.Context) string {
+	if apiKey, ok := CurrentAPIKey(c); ok {
+		return "apikey:" + strconv.FormatUint(uint64(apiKey.ID), 10)
+	}
+
+	if user, ok := CurrentUser(c); ok {
+		return "user:" + strconv.FormatUint(uint64(user.ID), 10)
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds arredonda a duração para cima, em segundos.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// refillRate retorna a taxa de reposição do bucket, em tokens por segundo.
+func refillRate(limit config.RateLimit) float64 {
+	return float64(limit.Requests) / float64(limit.Period)
+}
+
+// bucketResult monta o resultado a partir dos tokens restantes no bucket.
+func bucketResult(limit config.RateLimit, tokens float64, allowed bool) RateLimitResult {
+	rate := refillRate(limit)
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     secondsToDuration((float64(limit.Burst) - tokens) / rate),
+	}
+
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	return result
+}
+
+// secondsToDuration converte segundos fracionários em time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// tokenBucket é o estado de um bucket no MemoryRateLimitStore.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// full é o instante em que o bucket volta a ficar cheio
+	full time.Time
+}
+
+// MemoryRateLimitStore guarda os buckets em memória; os limites valem por
+// instância da aplicação.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore cria um store em memória.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take consome um token do bucket da chave.
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit config.RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = bucket
+	}
+
+	rate := refillRate(limit)
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	result := bucketResult(limit, bucket.tokens, allowed)
+	bucket.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep remove periodicamente os buckets que já voltaram a ficar cheios,
+// equivalentes a buckets inexistentes.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
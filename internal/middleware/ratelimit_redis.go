@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"golang/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitKeyPrefix é o prefixo das chaves de rate limiting no Redis.
+const rateLimitKeyPrefix = "ratelimit:"
+
+// takeTokenScript atualiza o token bucket atomicamente. O estado fica em um
+// hash com os tokens restantes e o instante da última atualização (em ms), e
+// expira quando o bucket voltaria a ficar cheio.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+if now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) * rate)
+	ts = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(ts))
+redis.call("PEXPIRE", KEYS[1], math.ceil((capacity - tokens) / rate) + 1)
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisRateLimitStore guarda os buckets no Redis, compartilhando os limites
+// entre as instâncias da aplicação.
+type RedisRateLimitStore struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+// NewRedisRateLimitStore cria um store sobre o cliente Redis.
+func NewRedisRateLimitStore(client redis.Scripter) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client, now: time.Now}
+}
+
+// Take consome um token do bucket da chave.
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit config.RateLimit) (RateLimitResult, error) {
+	ratePerMillisecond := refillRate(limit) / 1000
+
+	values, err := takeTokenScript.Run(ctx, s.client, []string{rateLimitKeyPrefix + key},
+		limit.Burst, ratePerMillisecond, s.now().UnixMilli()).Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to take rate limit token: %w", err) //nolint:wrapcheck
+	}
+
+	if len(values) != 2 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit script result: %v", values) //nolint:err113
+	}
+
+	allowed, _ := values[0].(int64)
+	raw, _ := values[1].(string)
+
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("invalid rate limit tokens %q: %w", raw, err) //nolint:wrapcheck
+	}
+
+	return bucketResult(limit, math.Max(tokens, 0), allowed == 1), nil
+}
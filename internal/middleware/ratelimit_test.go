@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang/internal/config"
+	"golang/internal/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock é um relógio controlado pelos testes.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTestRateLimitStore cria o store informado com o relógio dos testes. O
+// store Redis usa um servidor miniredis.
+func newTestRateLimitStore(t *testing.T, name string, clock *fakeClock) RateLimitStore {
+	t.Helper()
+
+	if name == "memory" {
+		store := NewMemoryRateLimitStore()
+		store.now = clock.Now
+
+		return store
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewRedisRateLimitStore(client)
+	store.now = clock.Now
+
+	return store
+}
+
+func TestRateLimitStores(t *testing.T) {
+	limit := config.RateLimit{Requests: 2, Period: 10, Burst: 3}
+
+	for _, name := range []string{"memory", "redis"} {
+		t.Run(name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+			store := newTestRateLimitStore(t, name, clock)
+			ctx := context.Background()
+
+			// A rajada inicial consome o bucket cheio
+			for remaining := 2; remaining >= 0; remaining-- {
+				result, err := store.Take(ctx, "client", limit)
+				require.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, 3, result.Limit)
+				assert.Equal(t, remaining, result.Remaining)
+			}
+
+			result, err := store.Take(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+			assert.Equal(t, 5*time.Second, result.RetryAfter)
+			assert.Equal(t, 15*time.Second, result.Reset)
+
+			// Outros clientes têm o próprio bucket
+			result, err = store.Take(ctx, "other", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			// Um token é reposto a cada 5 segundos
+			clock.Advance(5 * time.Second)
+
+			result, err = store.Take(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+
+			// O bucket nunca passa da capacidade
+			clock.Advance(time.Hour)
+
+			result, err = store.Take(ctx, "client", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 2, result.Remaining)
+		})
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := NewMemoryRateLimitStore()
+	store.now = clock.Now
+
+	limit := config.RateLimit{Requests: 60, Period: 60, Burst: 60}
+
+	_, err := store.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+
+	clock.Advance(2 * rateLimitSweepInterval)
+
+	_, err = store.Take(context.Background(), "other", limit)
+	require.NoError(t, err)
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "other")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	logger := NewLogger()
+	logger.SetOutput(httptest.NewRecorder())
+
+	limit := config.RateLimit{Requests: 1, Period: 60}
+
+	router := gin.New()
+	router.Use(ErrorHandlerMiddleware(logger), LanguageMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		if c.GetHeader("X-Test-Key") != "" {
+			c.Set(apiKeyContextKey, &models.APIKey{ID: 7})
+		}
+	}, RateLimitMiddleware(NewMemoryRateLimitStore(), logger, "test", limit), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(apiKey bool) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+		if apiKey {
+			req.Header.Set("X-Test-Key", "1")
+		}
+
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := request(false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1;w=60", w.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+
+	w = request(false)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"code":"rate_limited"`)
+
+	// A API key tem um orçamento separado do IP
+	w = request(true)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	t.Run("disabled", func(t *testing.T) {
+		handler := RateLimitMiddleware(NewMemoryRateLimitStore(), logger, "test", config.RateLimit{})
+
+		router := gin.New()
+		router.GET("/", handler, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
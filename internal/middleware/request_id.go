@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader é o header que transporta o ID da requisição.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita o tamanho do ID aceito do cliente.
+const maxRequestIDLength = 128
+
+// requestIDKey é a chave do ID da requisição no context.Context.
+type requestIDKey struct{}
+
+// RequestIDMiddleware aceita o X-Request-ID enviado pelo cliente ou gera um
+// novo, guarda-o no contexto da requisição e o devolve na resposta. Todo log
+// feito com esse contexto inclui o campo request_id.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+	})
+}
+
+// WithRequestID retorna uma cópia do contexto com o ID da requisição.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext retorna o ID da requisição guardado no contexto.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// validRequestID aceita IDs não vazios, de tamanho limitado e apenas com
+// caracteres ASCII visíveis, evitando injeção de conteúdo nos logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID gera um ID aleatório de 128 bits em hexadecimal.
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
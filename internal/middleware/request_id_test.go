@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "generated"},
+		{name: "accepted", header: "abc-123", expected: "abc-123"},
+		{name: "too long", header: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "invalid characters", header: "abc 123"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fromContext string
+
+			router := gin.New()
+			router.Use(RequestIDMiddleware())
+			router.GET("/", func(c *gin.Context) {
+				fromContext = RequestIDFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+			if tc.header != "" {
+				req.Header.Set(RequestIDHeader, tc.header)
+			}
+
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			assert.Equal(t, id, fromContext)
+
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, id)
+			} else {
+				assert.Len(t, id, 32)
+				assert.NotEqual(t, tc.header, id)
+			}
+		})
+	}
+}
+
+func TestLoggerIncludesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var out bytes.Buffer
+
+	logger := NewLogger()
+	logger.SetOutput(&out)
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggingMiddleware(logger))
+	router.GET("/", func(c *gin.Context) {
+		logger.WithContext(c.Request.Context()).Info("inside handler")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(RequestIDHeader, "req-42")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "req-42", entry["request_id"])
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifica os spans criados pelos middlewares.
+const tracerName = "golang/internal/middleware"
+
+// TracingMiddleware cria um span de servidor por requisição, continuando o
+// trace recebido no header traceparent (W3C Trace Context). O span é nomeado
+// pelo template da rota e guardado no contexto da requisição, de onde os
+// serviços e o GORM criam os spans filhos.
+func TracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		name := c.Request.Method + " " + route
+
+		if route == "" {
+			name = c.Request.Method
+		}
+
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.HTTPRoute(route),
+				semconv.ClientAddress(c.ClientIP()),
+				attribute.String("http.request_id", RequestIDFromContext(ctx)),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last().Err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useSpanRecorder instala um TracerProvider global que grava os spans,
+// restaurando o anterior ao fim do teste.
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	return recorder
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := useSpanRecorder(t)
+
+	var logs bytes.Buffer
+
+	logger := NewLogger()
+	logger.SetOutput(&logs)
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), TracingMiddleware())
+	router.GET("/items/:id", func(c *gin.Context) {
+		_, child := otel.Tracer("test").Start(c.Request.Context(), "child")
+		child.End()
+
+		logger.WithContext(c.Request.Context()).Info("inside handler")
+		c.Status(http.StatusInternalServerError)
+	})
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	req.Header.Set(RequestIDHeader, "req-7")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	child, server := spans[0], spans[1]
+
+	// O span do servidor continua o trace recebido
+	assert.Equal(t, "GET /items/:id", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, parentSpanID, server.Parent().SpanID().String())
+	assert.Contains(t, server.Attributes(), attribute.String("http.route", "/items/:id"))
+	assert.Contains(t, server.Attributes(), attribute.Int("http.response.status_code", http.StatusInternalServerError))
+	assert.Contains(t, server.Attributes(), attribute.String("http.request_id", "req-7"))
+	assert.Equal(t, "Error", server.Status().Code.String())
+
+	// Spans criados a partir do contexto da requisição são filhos do span do servidor
+	assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+
+	// Os logs da requisição carregam o trace
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, traceID, entry["trace_id"])
+	assert.Equal(t, server.SpanContext().SpanID().String(), entry["span_id"])
+	assert.Equal(t, "req-7", entry["request_id"])
+}
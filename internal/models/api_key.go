@@ -0,0 +1,86 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scopes representa uma lista de escopos armazenada como texto separado por vírgulas.
+type Scopes []string
+
+// Value implementa driver.Valuer.
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+// Scan implementa sql.Scanner.
+func (s *Scopes) Scan(value interface{}) error {
+	var raw string
+
+	switch v := value.(type) {
+	case nil:
+		*s = Scopes{}
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("unsupported scopes type %T", value) //nolint:wrapcheck
+	}
+
+	result := Scopes{}
+
+	for _, scope := range strings.Split(raw, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+
+	*s = result
+
+	return nil
+}
+
+// Contains indica se o escopo está na lista. O escopo "*" concede todos.
+func (s Scopes) Contains(scope string) bool {
+	for _, item := range s {
+		if item == scope || item == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIKey representa uma chave de API para clientes máquina.
+// Apenas o hash da chave é armazenado; o prefixo permite localizá-la.
+type APIKey struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Name        string     `json:"name" gorm:"not null"`
+	Prefix      string     `json:"prefix" gorm:"uniqueIndex;not null"`
+	KeyHash     string     `json:"-" gorm:"not null"`
+	Scopes      Scopes     `json:"scopes" gorm:"type:text"`
+	CreatedByID *uint      `json:"created_by_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsActive indica se a chave não foi revogada nem expirou.
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Origens de uma conversão registrada no histórico.
+const (
+	ConversionSourceSingle = "single"
+	ConversionSourceAll    = "all"
+	ConversionSourceBatch  = "batch"
+)
+
+// Conversion representa uma conversão de temperatura registrada para auditoria.
+// UserID e APIKeyID identificam quem solicitou; ambos são nulos para chamadas anônimas.
+type Conversion struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	FromUnit       string    `json:"from_unit" gorm:"index;not null"`
+	ToUnit         string    `json:"to_unit" gorm:"index;not null"`
+	Value          float64   `json:"value" gorm:"index;not null"`
+	ConvertedValue float64   `json:"converted_value" gorm:"not null"`
+	Precision      int       `json:"precision"`
+	Rounding       string    `json:"rounding"`
+	Source         string    `json:"source" gorm:"not null"`
+	UserID         *uint     `json:"user_id" gorm:"index"`
+	APIKeyID       *uint     `json:"api_key_id" gorm:"index"`
+	ClientIP       string    `json:"client_ip"`
+	CreatedAt      time.Time `json:"created_at" gorm:"index"`
+}
+
+// TableName especifica o nome da tabela.
+func (Conversion) TableName() string {
+	return "conversions"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken representa um refresh token emitido para um usuário.
+// Apenas o hash do token é armazenado.
+type RefreshToken struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy *uint      `json:"replaced_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela.
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsActive indica se o token ainda pode ser usado.
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// PermissionAll concede todas as permissões.
+const PermissionAll = "*"
+
+// Permissões conhecidas pela aplicação.
+const (
+	PermissionUsersRead     = "users:read"
+	PermissionUsersWrite    = "users:write"
+	PermissionUsersDelete   = "users:delete"
+	PermissionRolesManage   = "roles:manage"
+	PermissionAPIKeysManage = "api-keys:manage"
+	PermissionHistoryRead   = "history:read"
+)
+
+// Papéis padrão, criados pela migração 0002_seed_roles.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permission representa uma permissão no formato "recurso:ação".
+type Permission struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela.
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// Role representa um papel com um conjunto de permissões.
+type Role struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Name        string       `json:"name" gorm:"uniqueIndex;not null"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions,omitempty" gorm:"many2many:role_permissions"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela.
+func (Role) TableName() string {
+	return "roles"
+}
+
+// HasPermission indica se o papel concede a permissão.
+func (r *Role) HasPermission(name string) bool {
+	for _, permission := range r.Permissions {
+		if permission.Name == name || permission.Name == PermissionAll {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,6 +13,7 @@ type User struct {
 	Name      string         `json:"name" gorm:"not null"`
 	Password  string         `json:"-" gorm:"not null"` // "-" oculta o campo no JSON
 	Active    bool           `json:"active" gorm:"default:true"`
+	Roles     []Role         `json:"roles,omitempty" gorm:"many2many:user_roles"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
@@ -36,3 +37,15 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 	u.UpdatedAt = time.Now()
 	return nil
 }
+
+// HasPermission indica se algum papel do usuário concede a permissão.
+// Os papéis e suas permissões precisam ter sido carregados.
+func (u *User) HasPermission(name string) bool {
+	for i := range u.Roles {
+		if u.Roles[i].HasPermission(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+// Package repository isola o acesso aos dados dos serviços. Cada repositório
+// tem uma implementação com o GORM, usada em produção, e outra em memória,
+// para testar os serviços sem banco; as duas seguem o mesmo contrato,
+// verificado pelos testes do pacote.
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"golang/internal/models"
+)
+
+// ErrUserNotFound indica que o usuário não existe ou foi removido.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrEmailAlreadyExists indica que o email já está em uso por outro usuário.
+var ErrEmailAlreadyExists = errors.New("email already exists")
+
+// UserRepository persiste os usuários. Usuários removidos (soft delete) não
+// são retornados pelas buscas, mas seus emails continuam reservados.
+type UserRepository interface {
+	// Create grava um novo usuário, preenchendo ID e datas, e o vincula aos
+	// papéis existentes em Roles na mesma transação.
+	Create(ctx context.Context, user *models.User) error
+	// FindByID busca um usuário sem os papéis.
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	// FindByIDWithPermissions busca um usuário com papéis e permissões.
+	FindByIDWithPermissions(ctx context.Context, id uint) (*models.User, error)
+	// FindByEmail busca um usuário pelo email.
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	// EmailTaken indica se outro usuário, que não exceptID, usa o email.
+	EmailTaken(ctx context.Context, email string, exceptID uint) (bool, error)
+	// Update grava nome, email, senha e status do usuário.
+	Update(ctx context.Context, user *models.User) error
+	// UpdatePassword grava apenas o hash da senha.
+	UpdatePassword(ctx context.Context, id uint, hash string) error
+	// Delete remove o usuário (soft delete).
+	Delete(ctx context.Context, id uint) error
+	// List retorna uma página de usuários, ordenada por ID, e o total.
+	List(ctx context.Context, offset, limit int) ([]models.User, int64, error)
+}
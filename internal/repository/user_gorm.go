@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"golang/internal/database"
+	"golang/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// GormUserRepository implementa UserRepository com o GORM.
+type GormUserRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserRepository cria um repositório de usuários sobre a conexão.
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db: db}
+}
+
+// Create grava um novo usuário e os vínculos com os seus papéis, sem alterar
+// os papéis em si.
+func (r *GormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return userError(r.db.WithContext(ctx).Omit("Roles.*").Create(user).Error)
+}
+
+// FindByID busca um usuário sem os papéis.
+func (r *GormUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, userError(err)
+	}
+
+	return &user, nil
+}
+
+// FindByIDWithPermissions busca um usuário com papéis e permissões.
+func (r *GormUserRepository) FindByIDWithPermissions(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Preload("Roles.Permissions").First(&user, id).Error; err != nil {
+		return nil, userError(err)
+	}
+
+	return &user, nil
+}
+
+// FindByEmail busca um usuário pelo email.
+func (r *GormUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, userError(err)
+	}
+
+	return &user, nil
+}
+
+// EmailTaken indica se outro usuário usa o email. A consulta vai para o
+// primário: ela precede uma escrita, e as réplicas podem não ter os
+// cadastros mais recentes.
+func (r *GormUserRepository) EmailTaken(ctx context.Context, email string, exceptID uint) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(database.WithPrimary(ctx)).Model(&models.User{}).
+		Where("email = ? AND id <> ?", email, exceptID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Update grava nome, email, senha e status do usuário. Os papéis não são
+// alterados.
+func (r *GormUserRepository) Update(ctx context.Context, user *models.User) error {
+	result := r.db.WithContext(ctx).Model(user).
+		Select("email", "name", "password", "active", "updated_at").
+		Updates(user)
+
+	return affected(result)
+}
+
+// UpdatePassword grava apenas o hash da senha.
+func (r *GormUserRepository) UpdatePassword(ctx context.Context, id uint, hash string) error {
+	return affected(r.db.WithContext(ctx).Model(&models.User{ID: id}).Update("password", hash))
+}
+
+// Delete remove o usuário (soft delete).
+func (r *GormUserRepository) Delete(ctx context.Context, id uint) error {
+	return affected(r.db.WithContext(ctx).Delete(&models.User{}, id))
+}
+
+// List retorna uma página de usuários, ordenada por ID, e o total.
+func (r *GormUserRepository) List(ctx context.Context, offset, limit int) ([]models.User, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.User{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := r.db.WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// affected traduz o erro da escrita e trata nenhuma linha afetada como
+// usuário inexistente.
+func affected(result *gorm.DB) error {
+	if result.Error != nil {
+		return userError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// userError traduz os erros do GORM para os erros do repositório.
+func userError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrUserNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrEmailAlreadyExists
+	default:
+		return err
+	}
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"golang/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MemoryUserRepository implementa UserRepository em memória, para testes.
+// Reproduz o comportamento do banco: emails únicos, inclusive entre usuários
+// removidos, e usuários criados ativos.
+type MemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]models.User
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryUserRepository cria um repositório de usuários vazio.
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: make(map[uint]models.User), nextID: 1, now: time.Now}
+}
+
+// Create grava um novo usuário.
+func (r *MemoryUserRepository) Create(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return ErrEmailAlreadyExists
+		}
+	}
+
+	now := r.now()
+	user.ID = r.nextID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	// O banco aplica o default da coluna quando o campo é falso
+	user.Active = true
+
+	r.nextID++
+	r.users[user.ID] = clone(*user)
+
+	return nil
+}
+
+// FindByID busca um usuário sem os papéis.
+func (r *MemoryUserRepository) FindByID(_ context.Context, id uint) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.find(id)
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	user.Roles = nil
+
+	return &user, nil
+}
+
+// FindByIDWithPermissions busca um usuário com os papéis gravados em Create.
+func (r *MemoryUserRepository) FindByIDWithPermissions(_ context.Context, id uint) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.find(id)
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+// FindByEmail busca um usuário pelo email.
+func (r *MemoryUserRepository) FindByEmail(_ context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email && !user.DeletedAt.Valid {
+			user.Roles = nil
+			return &user, nil
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
+
+// EmailTaken indica se outro usuário usa o email.
+func (r *MemoryUserRepository) EmailTaken(_ context.Context, email string, exceptID uint) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, user := range r.users {
+		if user.Email == email && id != exceptID && !user.DeletedAt.Valid {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Update grava nome, email, senha e status do usuário.
+func (r *MemoryUserRepository) Update(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.find(user.ID)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	for id, existing := range r.users {
+		if existing.Email == user.Email && id != user.ID {
+			return ErrEmailAlreadyExists
+		}
+	}
+
+	user.UpdatedAt = r.now()
+
+	stored.Email = user.Email
+	stored.Name = user.Name
+	stored.Password = user.Password
+	stored.Active = user.Active
+	stored.UpdatedAt = user.UpdatedAt
+	r.users[user.ID] = stored
+
+	return nil
+}
+
+// UpdatePassword grava apenas o hash da senha.
+func (r *MemoryUserRepository) UpdatePassword(_ context.Context, id uint, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.find(id)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	stored.Password = hash
+	stored.UpdatedAt = r.now()
+	r.users[id] = stored
+
+	return nil
+}
+
+// Delete remove o usuário (soft delete).
+func (r *MemoryUserRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.find(id)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	stored.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.users[id] = stored
+
+	return nil
+}
+
+// List retorna uma página de usuários, ordenada por ID, e o total.
+func (r *MemoryUserRepository) List(_ context.Context, offset, limit int) ([]models.User, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0, len(r.users))
+
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid {
+			user.Roles = nil
+			users = append(users, user)
+		}
+	}
+
+	slices.SortFunc(users, func(a, b models.User) int { return int(a.ID) - int(b.ID) })
+
+	total := int64(len(users))
+	users = users[min(max(offset, 0), len(users)):]
+
+	if limit >= 0 && limit < len(users) {
+		users = users[:limit]
+	}
+
+	return users, total, nil
+}
+
+// find retorna uma cópia do usuário, se existir e não tiver sido removido.
+func (r *MemoryUserRepository) find(id uint) (models.User, bool) {
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return models.User{}, false
+	}
+
+	return clone(user), true
+}
+
+// clone copia o usuário sem compartilhar a lista de papéis.
+func clone(user models.User) models.User {
+	user.Roles = slices.Clone(user.Roles)
+	return user
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang/internal/database/dbtest"
+	"golang/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryUserRepository(t *testing.T) {
+	testUserRepository(t, func(*testing.T) UserRepository {
+		return NewMemoryUserRepository()
+	})
+}
+
+func TestGormUserRepository(t *testing.T) {
+	testUserRepository(t, func(t *testing.T) UserRepository {
+		t.Helper()
+
+		return NewGormUserRepository(dbtest.Open(t))
+	})
+}
+
+// testUserRepository é o contrato que toda implementação de UserRepository
+// precisa cumprir. newRepo retorna um repositório vazio.
+func testUserRepository(t *testing.T, newRepo func(t *testing.T) UserRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	create := func(t *testing.T, repo UserRepository, email string) *models.User {
+		t.Helper()
+
+		user := &models.User{Email: email, Name: "User " + email, Password: "hash"}
+		require.NoError(t, repo.Create(ctx, user))
+
+		return user
+	}
+
+	t.Run("create and find", func(t *testing.T) {
+		repo := newRepo(t)
+		user := create(t, repo, "ana@example.com")
+
+		assert.NotZero(t, user.ID)
+		assert.True(t, user.Active)
+		assert.False(t, user.CreatedAt.IsZero())
+		assert.False(t, user.UpdatedAt.IsZero())
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ana@example.com", found.Email)
+		assert.Equal(t, "User ana@example.com", found.Name)
+		assert.Equal(t, "hash", found.Password)
+		assert.True(t, found.Active)
+		assert.WithinDuration(t, user.CreatedAt, found.CreatedAt, time.Millisecond)
+
+		found, err = repo.FindByEmail(ctx, "ana@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+
+		found, err = repo.FindByIDWithPermissions(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+		assert.Empty(t, found.Roles)
+	})
+
+	t.Run("duplicate email", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, "ana@example.com")
+
+		err := repo.Create(ctx, &models.User{Email: "ana@example.com", Name: "Ana", Password: "hash"})
+		require.ErrorIs(t, err, ErrEmailAlreadyExists)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.FindByID(ctx, 42)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		_, err = repo.FindByIDWithPermissions(ctx, 42)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		_, err = repo.FindByEmail(ctx, "nobody@example.com")
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		require.ErrorIs(t, repo.Update(ctx, &models.User{ID: 42, Email: "x@example.com"}), ErrUserNotFound)
+		require.ErrorIs(t, repo.UpdatePassword(ctx, 42, "hash"), ErrUserNotFound)
+		require.ErrorIs(t, repo.Delete(ctx, 42), ErrUserNotFound)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		repo := newRepo(t)
+		ana := create(t, repo, "ana@example.com")
+
+		taken, err := repo.EmailTaken(ctx, "ana@example.com", 0)
+		require.NoError(t, err)
+		assert.True(t, taken)
+
+		taken, err = repo.EmailTaken(ctx, "ana@example.com", ana.ID)
+		require.NoError(t, err)
+		assert.False(t, taken, "the user's own email is not taken")
+
+		taken, err = repo.EmailTaken(ctx, "bia@example.com", 0)
+		require.NoError(t, err)
+		assert.False(t, taken)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := newRepo(t)
+		ana := create(t, repo, "ana@example.com")
+		create(t, repo, "bia@example.com")
+
+		ana.Name = "Ana Maria"
+		ana.Email = "ana.maria@example.com"
+		ana.Password = "new-hash"
+		ana.Active = false
+		require.NoError(t, repo.Update(ctx, ana))
+
+		found, err := repo.FindByID(ctx, ana.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Ana Maria", found.Name)
+		assert.Equal(t, "ana.maria@example.com", found.Email)
+		assert.Equal(t, "new-hash", found.Password)
+		assert.False(t, found.Active)
+
+		ana.Email = "bia@example.com"
+		require.ErrorIs(t, repo.Update(ctx, ana), ErrEmailAlreadyExists)
+	})
+
+	t.Run("update password", func(t *testing.T) {
+		repo := newRepo(t)
+		ana := create(t, repo, "ana@example.com")
+
+		require.NoError(t, repo.UpdatePassword(ctx, ana.ID, "rehashed"))
+
+		found, err := repo.FindByID(ctx, ana.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "rehashed", found.Password)
+		assert.Equal(t, "User ana@example.com", found.Name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ana := create(t, repo, "ana@example.com")
+
+		require.NoError(t, repo.Delete(ctx, ana.ID))
+		require.ErrorIs(t, repo.Delete(ctx, ana.ID), ErrUserNotFound)
+
+		_, err := repo.FindByID(ctx, ana.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		_, err = repo.FindByEmail(ctx, "ana@example.com")
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		taken, err := repo.EmailTaken(ctx, "ana@example.com", 0)
+		require.NoError(t, err)
+		assert.False(t, taken)
+
+		// O email de um usuário removido continua reservado
+		err = repo.Create(ctx, &models.User{Email: "ana@example.com", Name: "Ana", Password: "hash"})
+		require.ErrorIs(t, err, ErrEmailAlreadyExists)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		repo := newRepo(t)
+
+		var ids []uint
+		for i := range 5 {
+			ids = append(ids, create(t, repo, fmt.Sprintf("user%d@example.com", i)).ID)
+		}
+
+		require.NoError(t, repo.Delete(ctx, ids[1]))
+
+		users, total, err := repo.List(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		require.Len(t, users, 2)
+		assert.Equal(t, ids[2], users[0].ID)
+		assert.Equal(t, ids[3], users[1].ID)
+
+		users, total, err = repo.List(ctx, 10, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Empty(t, users)
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang/internal/auth"
+	"golang/internal/database"
+	"golang/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidAPIKey indica que a chave de API é desconhecida, expirada,
+	// revogada ou que o seu criador foi desativado.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrScopeNotHeld indica um escopo que o criador da chave não possui.
+	ErrScopeNotHeld = errors.New("scope not held by the api key creator")
+)
+
+// lastUsedResolution evita uma escrita no banco a cada requisição autenticada.
+const lastUsedResolution = time.Minute
+
+// APIKeyService gerencia chaves de API.
+type APIKeyService struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyService cria uma nova instância do APIKeyService.
+func NewAPIKeyService(db *gorm.DB) *APIKeyService {
+	return &APIKeyService{db: db}
+}
+
+// CreateAPIKey gera e persiste uma nova chave em nome do criador, que precisa
+// ter os papéis e permissões carregados. Uma chave não concede mais do que o
+// seu criador possui: cada escopo precisa ser uma permissão dele. A chave em
+// texto puro é retornada apenas aqui e não pode ser recuperada depois.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, creator *models.User, apiKey *models.APIKey) (string, error) {
+	for _, scope := range apiKey.Scopes {
+		if !creator.HasPermission(scope) {
+			return "", fmt.Errorf("%w: %s", ErrScopeNotHeld, scope) //nolint:wrapcheck
+		}
+	}
+
+	apiKey.CreatedByID = &creator.ID
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	apiKey.Prefix = prefix
+	apiKey.KeyHash = hash
+
+	if err := s.db.WithContext(ctx).Create(apiKey).Error; err != nil {
+		return "", fmt.Errorf("failed to create api key: %w", err) //nolint:wrapcheck
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys lista todas as chaves de API.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := s.db.WithContext(ctx).Order("id").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revoga uma chave de API.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uint) error {
+	var apiKey models.APIKey
+	if err := s.db.WithContext(database.WithPrimary(ctx)).First(&apiKey, id).Error; err != nil {
+		return err
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Model(&apiKey).Update("revoked_at", time.Now()).Error
+}
+
+// AuthenticateAPIKey valida uma chave de API e registra seu último uso. A
+// chave deixa de valer enquanto o seu criador estiver desativado ou removido.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	prefix, ok := auth.ParseAPIKey(key)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Uma réplica atrasada ainda aceitaria chaves revogadas ou de criadores
+	// desativados
+	ctx = database.WithPrimary(ctx)
+
+	var apiKey models.APIKey
+	if err := s.db.WithContext(ctx).Where("prefix = ?", prefix).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(auth.HashToken(key))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if apiKey.CreatedByID != nil {
+		active, err := s.creatorActive(ctx, *apiKey.CreatedByID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !active {
+			return nil, ErrInvalidAPIKey
+		}
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := s.db.WithContext(ctx).Model(&apiKey).UpdateColumn("last_used_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to update api key usage: %w", err) //nolint:wrapcheck
+		}
+	}
+
+	return &apiKey, nil
+}
+
+// creatorActive indica se o criador da chave existe e está ativo.
+func (s *APIKeyService) creatorActive(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+
+	err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND active = ?", userID, true).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to load api key creator: %w", err) //nolint:wrapcheck
+	}
+
+	return count > 0, nil
+}
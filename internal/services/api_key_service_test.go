@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"golang/internal/database/dbtest"
+	"golang/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyServiceCreateChecksScopes(t *testing.T) {
+	db := dbtest.Open(t)
+	service := NewAPIKeyService(db)
+	ctx := context.Background()
+
+	creator := &models.User{Email: "ana@example.com", Name: "Ana", Password: "hash"}
+	require.NoError(t, db.Create(creator).Error)
+
+	creator.Roles = []models.Role{{
+		Name:        "reader",
+		Permissions: []models.Permission{{Name: models.PermissionUsersRead}, {Name: models.PermissionAPIKeysManage}},
+	}}
+
+	key, err := service.CreateAPIKey(ctx, creator, &models.APIKey{
+		Name: "reader", Scopes: models.Scopes{models.PermissionUsersRead},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, key)
+
+	for _, scope := range []string{models.PermissionAll, models.PermissionRolesManage} {
+		_, err := service.CreateAPIKey(ctx, creator, &models.APIKey{Name: "escalate", Scopes: models.Scopes{scope}})
+		require.ErrorIs(t, err, ErrScopeNotHeld, scope)
+	}
+
+	keys, err := service.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].CreatedByID)
+	assert.Equal(t, creator.ID, *keys[0].CreatedByID)
+}
+
+func TestAPIKeyServiceAuthenticate(t *testing.T) {
+	db := dbtest.Open(t)
+	service := NewAPIKeyService(db)
+	ctx := context.Background()
+
+	creator := &models.User{Email: "ana@example.com", Name: "Ana", Password: "hash"}
+	require.NoError(t, db.Create(creator).Error)
+
+	apiKey := &models.APIKey{Name: "job"}
+	key, err := service.CreateAPIKey(ctx, creator, apiKey)
+	require.NoError(t, err)
+
+	authenticated, err := service.AuthenticateAPIKey(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, apiKey.ID, authenticated.ID)
+	assert.NotNil(t, authenticated.LastUsedAt)
+
+	_, err = service.AuthenticateAPIKey(ctx, key+"x")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	t.Run("inactive creator", func(t *testing.T) {
+		require.NoError(t, db.Model(creator).Update("active", false).Error)
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+		require.NoError(t, db.Model(creator).Update("active", true).Error)
+
+		_, err = service.AuthenticateAPIKey(ctx, key)
+		require.NoError(t, err, "the key works again once the creator is reactivated")
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		require.NoError(t, service.RevokeAPIKey(ctx, apiKey.ID))
+
+		_, err := service.AuthenticateAPIKey(ctx, key)
+		require.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+}
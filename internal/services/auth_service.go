@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang/internal/auth"
+	"golang/internal/database"
+	"golang/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInactiveUser indica que o usuário está desativado.
+var ErrInactiveUser = errors.New("user is inactive")
+
+// ErrInvalidRefreshToken indica que o refresh token é desconhecido, expirado ou revogado.
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// TokenPair representa os tokens emitidos no login e no refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// AuthService gerencia login e o ciclo de vida dos tokens.
+type AuthService struct {
+	db          *gorm.DB
+	userService *UserService
+	tokens      *auth.TokenManager
+}
+
+// NewAuthService cria uma nova instância do AuthService.
+func NewAuthService(db *gorm.DB, userService *UserService, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{
+		db:          db,
+		userService: userService,
+		tokens:      tokens,
+	}
+}
+
+// Login autentica o usuário por email e senha e emite um par de tokens.
+func (s *AuthService) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	// Senha e status lidos no primário: uma réplica atrasada aceitaria a senha
+	// antiga ou um usuário já desativado
+	user, err := s.userService.VerifyPassword(database.WithPrimary(ctx), email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.Active {
+		return nil, ErrInactiveUser
+	}
+
+	pair, _, err := s.issueTokens(s.db.WithContext(ctx), user)
+
+	return pair, err
+}
+
+// Refresh troca um refresh token válido por um novo par de tokens. O token
+// usado é revogado; a reutilização de um token já revogado revoga todos os
+// tokens do usuário, pois indica que ele pode ter vazado. A revogação só
+// acontece se o token ainda estiver ativo, então, entre refreshes
+// simultâneos do mesmo token, apenas um é aceito e os demais contam como
+// reutilização.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	var pair *TokenPair
+
+	var reusedBy uint
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored models.RefreshToken
+		if err := tx.Where("token_hash = ?", auth.HashToken(refreshToken)).First(&stored).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+
+			return err
+		}
+
+		now := time.Now()
+
+		if stored.RevokedAt != nil {
+			reusedBy = stored.UserID
+			return ErrInvalidRefreshToken
+		}
+
+		if !stored.IsActive(now) {
+			return ErrInvalidRefreshToken
+		}
+
+		var user models.User
+		if err := tx.First(&user, stored.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+
+			return err
+		}
+
+		if !user.Active {
+			return ErrInactiveUser
+		}
+
+		newPair, newToken, err := s.issueTokens(tx, &user)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", stored.ID).
+			Updates(map[string]interface{}{
+				"revoked_at":  now,
+				"replaced_by": newToken.ID,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", result.Error) //nolint:wrapcheck
+		}
+
+		// Outro refresh revogou o token depois da leitura acima
+		if result.RowsAffected != 1 {
+			reusedBy = stored.UserID
+			return ErrInvalidRefreshToken
+		}
+
+		pair = newPair
+
+		return nil
+	})
+	if err != nil {
+		// A revogação em cascata fica fora da transação, que foi desfeita
+		if reusedBy != 0 {
+			if revokeErr := s.revokeAll(s.db.WithContext(ctx), reusedBy, time.Now()); revokeErr != nil {
+				return nil, revokeErr
+			}
+		}
+
+		if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrInactiveUser) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("failed to refresh token: %w", err) //nolint:wrapcheck
+	}
+
+	return pair, nil
+}
+
+// Logout revoga o refresh token informado. Tokens desconhecidos são ignorados.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	err := s.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL", auth.HashToken(refreshToken)).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// RevokeAll revoga todos os refresh tokens ativos de um usuário, encerrando
+// as suas sessões. Usado na troca de senha e na desativação.
+func (s *AuthService) RevokeAll(ctx context.Context, userID uint) error {
+	return s.revokeAll(s.db.WithContext(ctx), userID, time.Now())
+}
+
+// revokeAll revoga os refresh tokens ativos do usuário na transação informada.
+func (s *AuthService) revokeAll(tx *gorm.DB, userID uint, now time.Time) error {
+	err := tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// issueTokens emite o par de tokens e persiste o hash do refresh token.
+func (s *AuthService) issueTokens(tx *gorm.DB, user *models.User) (*TokenPair, *models.RefreshToken, error) {
+	accessToken, err := s.tokens.IssueAccessToken(user.ID, user.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshToken, hash, err := s.tokens.GenerateRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored := &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.tokens.RefreshTTL()),
+	}
+
+	if err := tx.Create(stored).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to store refresh token: %w", err) //nolint:wrapcheck
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.tokens.AccessTTL().Seconds()),
+	}, stored, nil
+}
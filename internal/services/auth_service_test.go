@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"golang/internal/auth"
+	"golang/internal/config"
+	"golang/internal/database/dbtest"
+	"golang/internal/models"
+	"golang/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// newTestAuthService cria um AuthService sobre um banco de teste, com a
+// usuária ana@example.com cadastrada.
+func newTestAuthService(t *testing.T) (*AuthService, *gorm.DB, *models.User) {
+	t.Helper()
+
+	db := dbtest.Open(t)
+	users := NewUserService(repository.NewGormUserRepository(db), auth.NewBcryptHasher(bcrypt.MinCost))
+	tokens := auth.NewTokenManager(config.AuthConfig{
+		JWTSecret:       "test-secret",
+		JWTIssuer:       "test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+
+	user := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"}
+	require.NoError(t, users.CreateUser(context.Background(), user))
+
+	return NewAuthService(db, users, tokens), db, user
+}
+
+func TestAuthServiceLogin(t *testing.T) {
+	service, db, user := newTestAuthService(t)
+	ctx := context.Background()
+
+	pair, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.Equal(t, "Bearer", pair.TokenType)
+
+	_, err = service.Login(ctx, "ana@example.com", "wrong")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	require.NoError(t, db.Model(user).Update("active", false).Error)
+
+	_, err = service.Login(ctx, "ana@example.com", "Secret123!")
+	require.ErrorIs(t, err, ErrInactiveUser)
+}
+
+func TestAuthServiceRefreshRotation(t *testing.T) {
+	service, _, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	first, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+
+	second, err := service.Refresh(ctx, first.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	third, err := service.Refresh(ctx, second.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = service.Refresh(ctx, "unknown")
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	t.Run("reuse revokes every token", func(t *testing.T) {
+		_, err := service.Refresh(ctx, first.RefreshToken)
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+		_, err = service.Refresh(ctx, third.RefreshToken)
+		require.ErrorIs(t, err, ErrInvalidRefreshToken, "the newest token is revoked too")
+	})
+}
+
+func TestAuthServiceConcurrentRefresh(t *testing.T) {
+	service, _, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	pair, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+
+	const attempts = 4
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		refreshed []*TokenPair
+	)
+
+	for range attempts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			next, err := service.Refresh(ctx, pair.RefreshToken)
+			if err != nil {
+				assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+				return
+			}
+
+			mu.Lock()
+			refreshed = append(refreshed, next)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	require.Len(t, refreshed, 1, "only one refresh of the same token succeeds")
+
+	// Os demais contam como reutilização e revogam também o token emitido
+	_, err = service.Refresh(ctx, refreshed[0].RefreshToken)
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestAuthServiceLogoutAndRevokeAll(t *testing.T) {
+	service, _, user := newTestAuthService(t)
+	ctx := context.Background()
+
+	pair, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+
+	require.NoError(t, service.Logout(ctx, pair.RefreshToken))
+	require.NoError(t, service.Logout(ctx, "unknown"), "unknown tokens are ignored")
+
+	_, err = service.Refresh(ctx, pair.RefreshToken)
+	require.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	first, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+
+	second, err := service.Login(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+
+	require.NoError(t, service.RevokeAll(ctx, user.ID))
+
+	for _, token := range []string{first.RefreshToken, second.RefreshToken} {
+		_, err = service.Refresh(ctx, token)
+		require.ErrorIs(t, err, ErrInvalidRefreshToken)
+	}
+}
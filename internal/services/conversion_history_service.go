@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrHistoryQueueFull indica que o registro assíncrono está sobrecarregado e
+// as conversões foram descartadas.
+var ErrHistoryQueueFull = errors.New("conversion history queue is full")
+
+// ConversionHistoryFilter filtra o histórico de conversões. Campos vazios ou
+// nulos não restringem a consulta.
+type ConversionHistoryFilter struct {
+	// Unit casa com a unidade de origem ou de destino
+	Unit     string
+	FromUnit string
+	ToUnit   string
+	// MinValue e MaxValue limitam o valor de entrada (inclusivos)
+	MinValue *float64
+	MaxValue *float64
+	// Since e Until limitam a data da conversão (inclusivos)
+	Since    *time.Time
+	Until    *time.Time
+	UserID   *uint
+	APIKeyID *uint
+}
+
+// historyInsertBatchSize limita as linhas por INSERT: cada conversão usa
+// cerca de 11 parâmetros, e o Postgres aceita no máximo 65535 por instrução
+// (o SQLite, 32766).
+const historyInsertBatchSize = 100
+
+// historyBatch é um lote de conversões aguardando gravação assíncrona.
+type historyBatch struct {
+	ctx         context.Context //nolint:containedctx
+	conversions []models.Conversion
+}
+
+// ConversionHistoryService registra e consulta o histórico de conversões.
+// No modo assíncrono, os registros são gravados por um worker em segundo
+// plano; Close deve ser chamado para gravar os pendentes.
+type ConversionHistoryService struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	queue  chan historyBatch
+	done   chan struct{}
+	// mu protege closed e impede que Close feche a fila durante um envio
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewConversionHistoryService cria o serviço. Com async, as gravações passam
+// por uma fila de queueSize lotes; caso contrário, são feitas na chamada.
+func NewConversionHistoryService(db *gorm.DB, logger *logrus.Logger, async bool, queueSize int) *ConversionHistoryService {
+	s := &ConversionHistoryService{db: db, logger: logger}
+
+	if async && db != nil {
+		s.queue = make(chan historyBatch, queueSize)
+		s.done = make(chan struct{})
+
+		go s.worker()
+	}
+
+	return s
+}
+
+// Record grava as conversões no histórico. Sem banco configurado, não faz nada.
+// No modo assíncrono, a gravação mantém os valores do contexto (como o ID da
+// requisição), mas não é cancelada junto com ele. Depois de Close, as
+// gravações voltam a ser feitas na chamada.
+func (s *ConversionHistoryService) Record(ctx context.Context, conversions ...models.Conversion) error {
+	if s.db == nil || len(conversions) == 0 {
+		return nil
+	}
+
+	if s.queue == nil {
+		return s.insert(ctx, conversions)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Requisições que terminam após o desligamento não podem usar a fila fechada
+	if s.closed {
+		return s.insert(ctx, conversions)
+	}
+
+	select {
+	case s.queue <- historyBatch{ctx: context.WithoutCancel(ctx), conversions: conversions}:
+		return nil
+	default:
+		return fmt.Errorf("%w: dropped %d conversions", ErrHistoryQueueFull, len(conversions)) //nolint:wrapcheck
+	}
+}
+
+// List retorna uma página do histórico, das conversões mais recentes para as
+// mais antigas, e o total de registros que atendem ao filtro.
+func (s *ConversionHistoryService) List(ctx context.Context, filter ConversionHistoryFilter, offset, limit int) ([]models.Conversion, int64, error) {
+	query := applyConversionHistoryFilter(s.db.WithContext(ctx).Model(&models.Conversion{}), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var conversions []models.Conversion
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&conversions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return conversions, total, nil
+}
+
+// Close encerra o worker assíncrono após gravar os registros pendentes, ou
+// desiste quando o contexto expira.
+func (s *ConversionHistoryService) Close(ctx context.Context) error {
+	if s.queue == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to flush conversion history: %w", ctx.Err()) //nolint:wrapcheck
+	}
+}
+
+// worker grava os lotes da fila até que ela seja fechada.
+func (s *ConversionHistoryService) worker() {
+	defer close(s.done)
+
+	for batch := range s.queue {
+		if err := s.insert(batch.ctx, batch.conversions); err != nil {
+			s.logger.WithContext(batch.ctx).WithField("error", err).Error("Failed to record conversion history")
+		}
+	}
+}
+
+// insert grava as conversões em uma transação, em instruções de até
+// historyInsertBatchSize linhas.
+func (s *ConversionHistoryService) insert(ctx context.Context, conversions []models.Conversion) error {
+	if err := s.db.WithContext(ctx).CreateInBatches(&conversions, historyInsertBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to record conversions: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// applyConversionHistoryFilter adiciona as condições do filtro à consulta.
+func applyConversionHistoryFilter(query *gorm.DB, filter ConversionHistoryFilter) *gorm.DB {
+	if filter.Unit != "" {
+		query = query.Where("from_unit = ? OR to_unit = ?", filter.Unit, filter.Unit)
+	}
+
+	if filter.FromUnit != "" {
+		query = query.Where("from_unit = ?", filter.FromUnit)
+	}
+
+	if filter.ToUnit != "" {
+		query = query.Where("to_unit = ?", filter.ToUnit)
+	}
+
+	if filter.MinValue != nil {
+		query = query.Where("value >= ?", *filter.MinValue)
+	}
+
+	if filter.MaxValue != nil {
+		query = query.Where("value <= ?", *filter.MaxValue)
+	}
+
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+
+	if filter.Until != nil {
+		query = query.Where("created_at <= ?", *filter.Until)
+	}
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *filter.APIKeyID)
+	}
+
+	return query
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang/internal/database/dbtest"
+	"golang/internal/models"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversionHistoryServiceListFilters(t *testing.T) {
+	service := NewConversionHistoryService(dbtest.Open(t), logrus.New(), false, 0)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	userID := uint(7)
+
+	require.NoError(t, service.Record(ctx,
+		models.Conversion{FromUnit: "C", ToUnit: "F", Value: 0, ConvertedValue: 32, Source: models.ConversionSourceSingle, CreatedAt: base},
+		models.Conversion{FromUnit: "C", ToUnit: "K", Value: 10, ConvertedValue: 283.15, Source: models.ConversionSourceAll, CreatedAt: base.Add(time.Hour)},
+		models.Conversion{FromUnit: "F", ToUnit: "C", Value: 212, ConvertedValue: 100, Source: models.ConversionSourceBatch, UserID: &userID, CreatedAt: base.Add(2 * time.Hour)},
+	))
+
+	minValue, maxValue := 5.0, 100.0
+	since, until := base.Add(time.Hour), base.Add(2*time.Hour)
+
+	tests := []struct {
+		name   string
+		filter ConversionHistoryFilter
+		want   []float64
+	}{
+		{name: "no filter, newest first", want: []float64{212, 10, 0}},
+		{name: "unit matches either side", filter: ConversionHistoryFilter{Unit: "F"}, want: []float64{212, 0}},
+		{name: "from unit", filter: ConversionHistoryFilter{FromUnit: "C"}, want: []float64{10, 0}},
+		{name: "to unit", filter: ConversionHistoryFilter{ToUnit: "K"}, want: []float64{10}},
+		{name: "value range", filter: ConversionHistoryFilter{MinValue: &minValue, MaxValue: &maxValue}, want: []float64{10}},
+		{name: "date range", filter: ConversionHistoryFilter{Since: &since, Until: &until}, want: []float64{212, 10}},
+		{name: "user", filter: ConversionHistoryFilter{UserID: &userID}, want: []float64{212}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversions, total, err := service.List(ctx, tt.filter, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), total)
+
+			values := make([]float64, 0, len(conversions))
+			for _, conversion := range conversions {
+				values = append(values, conversion.Value)
+			}
+
+			assert.Equal(t, tt.want, values)
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		conversions, total, err := service.List(ctx, ConversionHistoryFilter{}, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total, "total ignores the page")
+		require.Len(t, conversions, 1)
+		assert.InDelta(t, 10.0, conversions[0].Value, 0)
+	})
+}
+
+func TestConversionHistoryServiceRecordLargeBatch(t *testing.T) {
+	service := NewConversionHistoryService(dbtest.Open(t), logrus.New(), false, 0)
+	ctx := context.Background()
+
+	// Em uma única instrução, passaria do limite de 32766 parâmetros do SQLite
+	conversions := make([]models.Conversion, 3000)
+	for i := range conversions {
+		conversions[i] = models.Conversion{FromUnit: "C", ToUnit: "F", Value: float64(i), Source: models.ConversionSourceBatch}
+	}
+
+	require.NoError(t, service.Record(ctx, conversions...))
+
+	_, total, err := service.List(ctx, ConversionHistoryFilter{}, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(conversions)), total)
+}
+
+func TestConversionHistoryServiceAsync(t *testing.T) {
+	service := NewConversionHistoryService(dbtest.Open(t), logrus.New(), true, 4)
+	ctx := context.Background()
+
+	require.NoError(t, service.Record(ctx, models.Conversion{FromUnit: "C", ToUnit: "F", Value: 100, ConvertedValue: 212, Source: models.ConversionSourceSingle}))
+	require.NoError(t, service.Close(ctx))
+
+	_, total, err := service.List(ctx, ConversionHistoryFilter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total, "Close flushes pending conversions")
+
+	t.Run("record after close", func(t *testing.T) {
+		require.NoError(t, service.Record(ctx, models.Conversion{FromUnit: "K", ToUnit: "C", Value: 0, ConvertedValue: -273.15, Source: models.ConversionSourceSingle}))
+		require.NoError(t, service.Close(ctx), "closing twice is a no-op")
+
+		_, total, err := service.List(ctx, ConversionHistoryFilter{}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total, "late conversions are written synchronously")
+	})
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang/internal/database"
+	"golang/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrRoleNotFound indica que o papel não existe.
+var ErrRoleNotFound = errors.New("role not found")
+
+// RBACService gerencia papéis e permissões de usuários.
+type RBACService struct {
+	db *gorm.DB
+}
+
+// NewRBACService cria uma nova instância do RBACService.
+func NewRBACService(db *gorm.DB) *RBACService {
+	return &RBACService{db: db}
+}
+
+// ListRoles lista os papéis com suas permissões.
+func (s *RBACService) ListRoles(ctx context.Context) ([]models.Role, error) {
+	var roles []models.Role
+	if err := s.db.WithContext(ctx).Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// DefaultRole retorna o papel inicial dos usuários cadastrados pela API.
+// Colocado em User.Roles antes do cadastro, o papel é gravado na mesma
+// transação que o usuário. O papel admin nunca é atribuído no cadastro: o
+// primeiro administrador é promovido com "migrate grant-admin".
+func (s *RBACService) DefaultRole(ctx context.Context) (*models.Role, error) {
+	var role models.Role
+	if err := s.db.WithContext(database.WithPrimary(ctx)).Where("name = ?", models.RoleUser).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// AssignRole atribui um papel a um usuário.
+func (s *RBACService) AssignRole(ctx context.Context, userID uint, roleName string) error {
+	user, role, err := s.findUserAndRole(ctx, userID, roleName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Model(user).Association("Roles").Append(role); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// RemoveRole remove um papel de um usuário.
+func (s *RBACService) RemoveRole(ctx context.Context, userID uint, roleName string) error {
+	user, role, err := s.findUserAndRole(ctx, userID, roleName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Model(user).Association("Roles").Delete(role); err != nil {
+		return fmt.Errorf("failed to remove role: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// findUserAndRole carrega o usuário e o papel pelo nome, do primário, já que
+// a leitura precede uma escrita.
+func (s *RBACService) findUserAndRole(ctx context.Context, userID uint, roleName string) (*models.User, *models.Role, error) {
+	ctx = database.WithPrimary(ctx)
+
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var role models.Role
+	if err := s.db.WithContext(ctx).Where("name = ?", roleName).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRoleNotFound
+		}
+
+		return nil, nil, err
+	}
+
+	return &user, &role, nil
+}
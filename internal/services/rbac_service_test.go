@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"golang/internal/auth"
+	"golang/internal/database/dbtest"
+	"golang/internal/models"
+	"golang/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// newTestRBACService cria o RBACService e o UserService sobre o mesmo banco
+// de teste, com os papéis padrão da migração.
+func newTestRBACService(t *testing.T) (*RBACService, *UserService, *gorm.DB) {
+	t.Helper()
+
+	db := dbtest.Open(t)
+	users := NewUserService(repository.NewGormUserRepository(db), auth.NewBcryptHasher(bcrypt.MinCost))
+
+	return NewRBACService(db), users, db
+}
+
+func TestRBACServiceListRoles(t *testing.T) {
+	service, _, _ := newTestRBACService(t)
+
+	roles, err := service.ListRoles(context.Background())
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+
+	assert.Equal(t, models.RoleAdmin, roles[0].Name)
+	assert.True(t, roles[0].HasPermission(models.PermissionRolesManage), "admin has every permission")
+	assert.Equal(t, models.RoleUser, roles[1].Name)
+	assert.Empty(t, roles[1].Permissions)
+}
+
+func TestRBACServiceCreateUserWithDefaultRole(t *testing.T) {
+	service, users, db := newTestRBACService(t)
+	ctx := context.Background()
+
+	role, err := service.DefaultRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleUser, role.Name)
+
+	user := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!", Roles: []models.Role{*role}}
+	require.NoError(t, users.CreateUser(ctx, user))
+
+	loaded, err := users.GetUserWithPermissions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Roles, 1)
+	assert.Equal(t, models.RoleUser, loaded.Roles[0].Name)
+
+	t.Run("role and user are created together", func(t *testing.T) {
+		// O vínculo com um papel inexistente falha e desfaz o cadastro
+		orphan := &models.User{
+			Email: "bia@example.com", Name: "Bia", Password: "Secret123!",
+			Roles: []models.Role{{ID: 999, Name: "missing"}},
+		}
+		require.Error(t, users.CreateUser(ctx, orphan))
+
+		var count int64
+		require.NoError(t, db.Model(&models.User{}).Where("email = ?", "bia@example.com").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRBACServiceAssignAndRemoveRole(t *testing.T) {
+	service, users, _ := newTestRBACService(t)
+	ctx := context.Background()
+
+	user := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"}
+	require.NoError(t, users.CreateUser(ctx, user))
+
+	require.NoError(t, service.AssignRole(ctx, user.ID, models.RoleAdmin))
+	require.NoError(t, service.AssignRole(ctx, user.ID, models.RoleAdmin), "assigning twice is a no-op")
+
+	loaded, err := users.GetUserWithPermissions(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Roles, 1)
+	assert.True(t, loaded.HasPermission(models.PermissionUsersDelete))
+
+	require.NoError(t, service.RemoveRole(ctx, user.ID, models.RoleAdmin))
+
+	loaded, err = users.GetUserWithPermissions(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Roles)
+	assert.False(t, loaded.HasPermission(models.PermissionUsersDelete))
+
+	require.ErrorIs(t, service.AssignRole(ctx, user.ID, "missing"), ErrRoleNotFound)
+	require.ErrorIs(t, service.AssignRole(ctx, 999, models.RoleAdmin), gorm.ErrRecordNotFound)
+}
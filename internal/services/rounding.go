@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode define como os resultados das conversões são arredondados.
+type RoundingMode string
+
+// Modos de arredondamento suportados.
+const (
+	// RoundingHalfUp arredonda a metade para longe do zero (2.675 → 2.68, -2.675 → -2.68).
+	RoundingHalfUp RoundingMode = "half_up"
+	// RoundingHalfEven arredonda a metade para o dígito par (2.665 → 2.66, 2.675 → 2.68).
+	RoundingHalfEven RoundingMode = "half_even"
+	// RoundingTruncate descarta os dígitos excedentes (2.679 → 2.67).
+	RoundingTruncate RoundingMode = "truncate"
+	// RoundingNone não arredonda; a precisão é ignorada.
+	RoundingNone RoundingMode = "none"
+)
+
+// Limites e valores padrão de precisão.
+const (
+	DefaultPrecision = 2
+	MaxPrecision     = 15
+)
+
+// Erros de validação das opções de arredondamento.
+var (
+	ErrInvalidPrecision    = errors.New("invalid precision")
+	ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+)
+
+// ConversionOptions controla a precisão e o arredondamento de uma conversão,
+// além do idioma das fórmulas (vazio usa o idioma padrão).
+type ConversionOptions struct {
+	Precision int
+	Rounding  RoundingMode
+	Language  string
+}
+
+// DefaultConversionOptions retorna as opções usadas quando nada é informado:
+// 2 casas decimais, arredondando a metade para longe do zero.
+func DefaultConversionOptions() ConversionOptions {
+	return ConversionOptions{
+		Precision: DefaultPrecision,
+		Rounding:  RoundingHalfUp,
+	}
+}
+
+// NewConversionOptions valida e monta as opções, aplicando os padrões para
+// valores não informados (precision nil ou rounding vazio).
+func NewConversionOptions(precision *int, rounding string) (ConversionOptions, error) {
+	opts := DefaultConversionOptions()
+
+	if precision != nil {
+		if *precision < 0 || *precision > MaxPrecision {
+			return opts, fmt.Errorf("%w: %d (expected 0-%d)", ErrInvalidPrecision, *precision, MaxPrecision) //nolint:wrapcheck
+		}
+
+		opts.Precision = *precision
+	}
+
+	if rounding != "" {
+		mode := RoundingMode(rounding)
+		switch mode {
+		case RoundingHalfUp, RoundingHalfEven, RoundingTruncate, RoundingNone:
+			opts.Rounding = mode
+		default:
+			return opts, fmt.Errorf("%w: %q", ErrInvalidRoundingMode, rounding) //nolint:wrapcheck
+		}
+	}
+
+	return opts, nil
+}
+
+// Round arredonda o valor conforme as opções. O arredondamento é decimal e
+// exato: opera sobre a menor representação decimal do float64, de modo que
+// 2.675 com 2 casas em half_up resulta em 2.68, e não 2.67.
+func (o ConversionOptions) Round(value float64) float64 {
+	if o.Rounding == RoundingNone {
+		return value
+	}
+
+	exact, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	if !ok {
+		return value
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(o.Precision)), nil)
+	exact.Mul(exact, new(big.Rat).SetInt(scale))
+
+	quotient, remainder := new(big.Int).QuoRem(exact.Num(), exact.Denom(), new(big.Int))
+
+	if o.Rounding != RoundingTruncate && remainder.Sign() != 0 {
+		// Comparar 2×|resto| com o denominador para saber se passou da metade
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+
+		cmp := twice.Cmp(exact.Denom())
+		if cmp > 0 || (cmp == 0 && (o.Rounding == RoundingHalfUp || quotient.Bit(0) == 1)) {
+			quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+		}
+	}
+
+	result, _ := new(big.Rat).SetFrac(quotient, scale).Float64()
+
+	return result
+}
+
+// Format formata o valor com a precisão das opções. Sem arredondamento,
+// usa a menor representação decimal exata.
+func (o ConversionOptions) Format(value float64) string {
+	if o.Rounding == RoundingNone {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return strconv.FormatFloat(o.Round(value), 'f', o.Precision, 64)
+}
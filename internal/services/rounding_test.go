@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversionOptions_Round(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     float64
+		precision int
+		mode      RoundingMode
+		expected  float64
+	}{
+		{"half up", 2.675, 2, RoundingHalfUp, 2.68},
+		{"half up negative", -2.675, 2, RoundingHalfUp, -2.68},
+		{"half even down", 2.665, 2, RoundingHalfEven, 2.66},
+		{"half even up", 2.675, 2, RoundingHalfEven, 2.68},
+		{"half even negative", -2.665, 2, RoundingHalfEven, -2.66},
+		{"half even not half", 2.6651, 2, RoundingHalfEven, 2.67},
+		{"truncate", 2.679, 2, RoundingTruncate, 2.67},
+		{"truncate negative", -2.679, 2, RoundingTruncate, -2.67},
+		{"zero precision", 2.5, 0, RoundingHalfUp, 3},
+		{"zero precision half even", 2.5, 0, RoundingHalfEven, 2},
+		{"high precision", 26.850000000000023, 10, RoundingHalfUp, 26.85},
+		{"none", 26.850000000000023, 2, RoundingNone, 26.850000000000023},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := ConversionOptions{Precision: tc.precision, Rounding: tc.mode}
+			assert.Equal(t, tc.expected, opts.Round(tc.value))
+		})
+	}
+}
+
+func TestConversionOptions_Format(t *testing.T) {
+	assert.Equal(t, "2.68", ConversionOptions{Precision: 2, Rounding: RoundingHalfUp}.Format(2.675))
+	assert.Equal(t, "3.0000", ConversionOptions{Precision: 4, Rounding: RoundingTruncate}.Format(3))
+	assert.Equal(t, "0.1", ConversionOptions{Precision: 2, Rounding: RoundingNone}.Format(0.1))
+}
+
+func TestNewConversionOptions(t *testing.T) {
+	opts, err := NewConversionOptions(nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConversionOptions(), opts)
+
+	precision := 4
+	opts, err = NewConversionOptions(&precision, "half_even")
+	require.NoError(t, err)
+	assert.Equal(t, ConversionOptions{Precision: 4, Rounding: RoundingHalfEven}, opts)
+
+	precision = MaxPrecision + 1
+	_, err = NewConversionOptions(&precision, "")
+	assert.ErrorIs(t, err, ErrInvalidPrecision)
+
+	_, err = NewConversionOptions(nil, "bankers")
+	assert.ErrorIs(t, err, ErrInvalidRoundingMode)
+}
+
+func TestConvertTemperature_RoundTripWithPrecision(t *testing.T) {
+	service := NewTemperatureService()
+	precision := 10
+
+	toCelsius, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:     300,
+		FromUnit:  "kelvin",
+		ToUnit:    "celsius",
+		Precision: &precision,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 26.85, toCelsius.ConvertedValue)
+	assert.Contains(t, toCelsius.Formula, "= 26.8500000000")
+
+	toKelvin, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:     toCelsius.ConvertedValue,
+		FromUnit:  "celsius",
+		ToUnit:    "kelvin",
+		Precision: &precision,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 300.0, toKelvin.ConvertedValue)
+}
+
+func TestConvertTemperature_RoundingInFormula(t *testing.T) {
+	service := NewTemperatureService()
+	precision := 1
+
+	resp, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:     37.777777,
+		FromUnit:  "celsius",
+		ToUnit:    "fahrenheit",
+		Precision: &precision,
+		Rounding:  "truncate",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 99.9, resp.ConvertedValue)
+	assert.Equal(t, "°F = (°C × 9/5) + 32 = (37.7 × 9/5) + 32 = 99.9", resp.Formula)
+	assert.Equal(t, 1, resp.Precision)
+	assert.Equal(t, "truncate", resp.Rounding)
+}
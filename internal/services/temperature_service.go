@@ -1,23 +1,74 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+
+	"golang/internal/i18n"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Erros retornados pelas conversões de temperatura.
+var (
+	// ErrUnknownUnit indica uma unidade que não está no registro.
+	ErrUnknownUnit = errors.New("unknown temperature unit")
+	// ErrBelowAbsoluteZero indica uma temperatura abaixo de 0 K.
+	ErrBelowAbsoluteZero = errors.New("temperature below absolute zero")
+	// ErrNonFiniteValue indica um valor NaN ou infinito, informado ou
+	// resultante da conversão (1e308 °C, por exemplo, não cabe em °F).
+	ErrNonFiniteValue = errors.New("temperature value must be finite")
 )
 
+// absoluteZeroTolerance absorve erros de ponto flutuante na conversão para
+// Kelvin, para que -459.67 °F não seja rejeitado por -1e-13 K.
+const absoluteZeroTolerance = 1e-9
+
 // TemperatureService fornece funcionalidades para conversão de temperatura
-type TemperatureService struct{}
+type TemperatureService struct {
+	units    *UnitRegistry
+	observer ConversionObserver
+}
+
+// ConversionObserver é notificado a cada conversão realizada, como as
+// métricas de conversões por par de unidades.
+type ConversionObserver interface {
+	ObserveConversion(fromUnit, toUnit string)
+}
 
 // NewTemperatureService cria uma nova instância do serviço de temperatura
 func NewTemperatureService() *TemperatureService {
-	return &TemperatureService{}
+	return NewTemperatureServiceWithRegistry(DefaultUnitRegistry())
+}
+
+// NewTemperatureServiceWithRegistry cria o serviço com um registro de unidades próprio
+func NewTemperatureServiceWithRegistry(units *UnitRegistry) *TemperatureService {
+	return &TemperatureService{units: units}
+}
+
+// SetObserver define quem é notificado das conversões realizadas.
+func (s *TemperatureService) SetObserver(observer ConversionObserver) {
+	s.observer = observer
+}
+
+// Units retorna o registro de unidades do serviço
+func (s *TemperatureService) Units() *UnitRegistry {
+	return s.units
 }
 
 // TemperatureConversionRequest representa a requisição de conversão
 type TemperatureConversionRequest struct {
 	Value    float64 `json:"value" binding:"required"`
-	FromUnit string  `json:"from_unit" binding:"required,oneof=kelvin celsius fahrenheit"`
-	ToUnit   string  `json:"to_unit" binding:"required,oneof=kelvin celsius fahrenheit"`
+	FromUnit string  `json:"from_unit" binding:"required,temperature_unit"`
+	ToUnit   string  `json:"to_unit" binding:"required,temperature_unit"`
+	// Precision é o número de casas decimais (0-15, padrão 2)
+	Precision *int `json:"precision,omitempty"`
+	// Rounding é o modo de arredondamento: half_up (padrão), half_even, truncate ou none
+	Rounding string `json:"rounding,omitempty"`
+	// Language é o idioma dos textos da resposta, negociado pela API
+	Language string `json:"-"`
 }
 
 // TemperatureConversionResponse representa a resposta de conversão
@@ -27,54 +78,52 @@ type TemperatureConversionResponse struct {
 	ConvertedValue float64 `json:"converted_value"`
 	ConvertedUnit  string  `json:"converted_unit"`
 	Formula        string  `json:"formula"`
+	Precision      int     `json:"precision"`
+	Rounding       string  `json:"rounding"`
 }
 
 // ConvertTemperature converte uma temperatura de uma unidade para outra
-func (s *TemperatureService) ConvertTemperature(req *TemperatureConversionRequest) (*TemperatureConversionResponse, error) {
-	var convertedValue float64
-	var formula string
-
-	switch req.FromUnit {
-	case "celsius":
-		switch req.ToUnit {
-		case "fahrenheit":
-			convertedValue = (req.Value * 9 / 5) + 32
-			formula = fmt.Sprintf("°F = (°C × 9/5) + 32 = (%.2f × 9/5) + 32 = %.2f", req.Value, convertedValue)
-		case "kelvin":
-			convertedValue = req.Value + 273.15
-			formula = fmt.Sprintf("K = °C + 273.15 = %.2f + 273.15 = %.2f", req.Value, convertedValue)
-		case "celsius":
-			convertedValue = req.Value
-			formula = "Mesma unidade, sem conversão necessária"
-		}
-	case "fahrenheit":
-		switch req.ToUnit {
-		case "celsius":
-			convertedValue = (req.Value - 32) * 5 / 9
-			formula = fmt.Sprintf("°C = (°F - 32) × 5/9 = (%.2f - 32) × 5/9 = %.2f", req.Value, convertedValue)
-		case "kelvin":
-			convertedValue = (req.Value-32)*5/9 + 273.15
-			formula = fmt.Sprintf("K = (°F - 32) × 5/9 + 273.15 = (%.2f - 32) × 5/9 + 273.15 = %.2f", req.Value, convertedValue)
-		case "fahrenheit":
-			convertedValue = req.Value
-			formula = "Mesma unidade, sem conversão necessária"
-		}
-	case "kelvin":
-		switch req.ToUnit {
-		case "celsius":
-			convertedValue = req.Value - 273.15
-			formula = fmt.Sprintf("°C = K - 273.15 = %.2f - 273.15 = %.2f", req.Value, convertedValue)
-		case "fahrenheit":
-			convertedValue = (req.Value-273.15)*9/5 + 32
-			formula = fmt.Sprintf("°F = (K - 273.15) × 9/5 + 32 = (%.2f - 273.15) × 9/5 + 32 = %.2f", req.Value, convertedValue)
-		case "kelvin":
-			convertedValue = req.Value
-			formula = "Mesma unidade, sem conversão necessária"
-		}
+func (s *TemperatureService) ConvertTemperature(ctx context.Context, req *TemperatureConversionRequest) (_ *TemperatureConversionResponse, err error) {
+	_, span := startSpan(ctx, "TemperatureService.ConvertTemperature",
+		attribute.String("temperature.from_unit", req.FromUnit),
+		attribute.String("temperature.to_unit", req.ToUnit))
+	defer func() { endSpan(span, err) }()
+
+	opts, err := NewConversionOptions(req.Precision, req.Rounding)
+	if err != nil {
+		return nil, err
 	}
 
-	// Arredondar para 2 casas decimais
-	convertedValue = math.Round(convertedValue*100) / 100
+	opts.Language = req.Language
+
+	from, err := s.validate(req.Value, req.FromUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	to, ok := s.units.Get(req.ToUnit)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownUnit, req.ToUnit) //nolint:wrapcheck
+	}
+
+	convertedValue := opts.Round(s.units.Convert(req.Value, from, to))
+	if math.IsNaN(convertedValue) || math.IsInf(convertedValue, 0) {
+		return nil, fmt.Errorf("%w: %v %s overflows in %s", //nolint:wrapcheck
+			ErrNonFiniteValue, req.Value, from.Name, to.Name)
+	}
+
+	if s.observer != nil {
+		s.observer.ObserveConversion(from.Name, to.Name)
+	}
+
+	formula := i18n.T(opts.Language, i18n.SameUnitFormula)
+	if from.Name != to.Name {
+		formula = fmt.Sprintf("%s = %s = %s = %s",
+			to.Symbol,
+			s.units.Formula(from, to, from.Symbol),
+			s.units.Formula(from, to, opts.Format(req.Value)),
+			opts.Format(convertedValue))
+	}
 
 	return &TemperatureConversionResponse{
 		OriginalValue:  req.Value,
@@ -82,6 +131,8 @@ func (s *TemperatureService) ConvertTemperature(req *TemperatureConversionReques
 		ConvertedValue: convertedValue,
 		ConvertedUnit:  req.ToUnit,
 		Formula:        formula,
+		Precision:      opts.Precision,
+		Rounding:       string(opts.Rounding),
 	}, nil
 }
 
@@ -91,29 +142,46 @@ type AllConversionsResponse struct {
 	OriginalUnit  string             `json:"original_unit"`
 	Conversions   map[string]float64 `json:"conversions"`
 	Formulas      map[string]string  `json:"formulas"`
+	Precision     int                `json:"precision"`
+	Rounding      string             `json:"rounding"`
 }
 
 // GetAllConversions converte um valor para todas as unidades disponíveis
-func (s *TemperatureService) GetAllConversions(value float64, fromUnit string) (*AllConversionsResponse, error) {
+func (s *TemperatureService) GetAllConversions(ctx context.Context, value float64, fromUnit string) (*AllConversionsResponse, error) {
+	return s.GetAllConversionsWithOptions(ctx, value, fromUnit, DefaultConversionOptions())
+}
+
+// GetAllConversionsWithOptions converte um valor para todas as unidades
+// disponíveis com a precisão e o arredondamento informados
+func (s *TemperatureService) GetAllConversionsWithOptions(ctx context.Context, value float64, fromUnit string, opts ConversionOptions) (_ *AllConversionsResponse, err error) {
+	ctx, span := startSpan(ctx, "TemperatureService.GetAllConversions",
+		attribute.String("temperature.from_unit", fromUnit))
+	defer func() { endSpan(span, err) }()
+
+	if _, err := s.validate(value, fromUnit); err != nil {
+		return nil, err
+	}
+
 	conversions := make(map[string]float64)
 	formulas := make(map[string]string)
 
-	units := []string{"celsius", "fahrenheit", "kelvin"}
-
-	for _, unit := range units {
+	for _, unit := range s.units.Names() {
 		if unit == fromUnit {
 			conversions[unit] = value
-			formulas[unit] = "Mesma unidade, sem conversão necessária"
+			formulas[unit] = i18n.T(opts.Language, i18n.SameUnitFormula)
 			continue
 		}
 
 		req := &TemperatureConversionRequest{
-			Value:    value,
-			FromUnit: fromUnit,
-			ToUnit:   unit,
+			Value:     value,
+			FromUnit:  fromUnit,
+			ToUnit:    unit,
+			Precision: &opts.Precision,
+			Rounding:  string(opts.Rounding),
+			Language:  opts.Language,
 		}
 
-		resp, err := s.ConvertTemperature(req)
+		resp, err := s.ConvertTemperature(ctx, req)
 		if err != nil {
 			return nil, err
 		}
@@ -127,5 +195,25 @@ func (s *TemperatureService) GetAllConversions(value float64, fromUnit string) (
 		OriginalUnit:  fromUnit,
 		Conversions:   conversions,
 		Formulas:      formulas,
+		Precision:     opts.Precision,
+		Rounding:      string(opts.Rounding),
 	}, nil
 }
+
+// validate verifica se a unidade existe e se o valor é fisicamente possível.
+func (s *TemperatureService) validate(value float64, unitName string) (TemperatureUnit, error) {
+	unit, ok := s.units.Get(unitName)
+	if !ok {
+		return TemperatureUnit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, unitName) //nolint:wrapcheck
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return TemperatureUnit{}, fmt.Errorf("%w: %v", ErrNonFiniteValue, value) //nolint:wrapcheck
+	}
+
+	if unit.ToKelvin(value) < -absoluteZeroTolerance {
+		return TemperatureUnit{}, fmt.Errorf("%w: %v %s", ErrBelowAbsoluteZero, value, unit.Name) //nolint:wrapcheck
+	}
+
+	return unit, nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,7 +23,7 @@ func TestConvertTemperature_CelsiusToFahrenheit(t *testing.T) {
 		ToUnit:   "fahrenheit",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 25.0, resp.OriginalValue)
@@ -40,7 +42,7 @@ func TestConvertTemperature_CelsiusToKelvin(t *testing.T) {
 		ToUnit:   "kelvin",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 0.0, resp.OriginalValue)
@@ -59,7 +61,7 @@ func TestConvertTemperature_FahrenheitToCelsius(t *testing.T) {
 		ToUnit:   "celsius",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 212.0, resp.OriginalValue)
@@ -78,7 +80,7 @@ func TestConvertTemperature_FahrenheitToKelvin(t *testing.T) {
 		ToUnit:   "kelvin",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 32.0, resp.OriginalValue)
@@ -97,7 +99,7 @@ func TestConvertTemperature_KelvinToCelsius(t *testing.T) {
 		ToUnit:   "celsius",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 373.15, resp.OriginalValue)
@@ -116,7 +118,7 @@ func TestConvertTemperature_KelvinToFahrenheit(t *testing.T) {
 		ToUnit:   "fahrenheit",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 273.15, resp.OriginalValue)
@@ -135,7 +137,7 @@ func TestConvertTemperature_SameUnit(t *testing.T) {
 		ToUnit:   "celsius",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	assert.Equal(t, 25.0, resp.OriginalValue)
@@ -154,7 +156,7 @@ func TestConvertTemperature_DecimalPrecision(t *testing.T) {
 		ToUnit:   "fahrenheit",
 	}
 
-	resp, err := service.ConvertTemperature(req)
+	resp, err := service.ConvertTemperature(context.Background(), req)
 	require.NoError(t, err)
 
 	// Deve arredondar para 2 casas decimais
@@ -164,7 +166,7 @@ func TestConvertTemperature_DecimalPrecision(t *testing.T) {
 func TestGetAllConversions_Celsius(t *testing.T) {
 	service := NewTemperatureService()
 
-	resp, err := service.GetAllConversions(25.0, "celsius")
+	resp, err := service.GetAllConversions(context.Background(), 25.0, "celsius")
 	require.NoError(t, err)
 
 	assert.Equal(t, 25.0, resp.OriginalValue)
@@ -184,7 +186,7 @@ func TestGetAllConversions_Celsius(t *testing.T) {
 func TestGetAllConversions_Fahrenheit(t *testing.T) {
 	service := NewTemperatureService()
 
-	resp, err := service.GetAllConversions(212.0, "fahrenheit")
+	resp, err := service.GetAllConversions(context.Background(), 212.0, "fahrenheit")
 	require.NoError(t, err)
 
 	assert.Equal(t, 212.0, resp.OriginalValue)
@@ -199,7 +201,7 @@ func TestGetAllConversions_Fahrenheit(t *testing.T) {
 func TestGetAllConversions_Kelvin(t *testing.T) {
 	service := NewTemperatureService()
 
-	resp, err := service.GetAllConversions(273.15, "kelvin")
+	resp, err := service.GetAllConversions(context.Background(), 273.15, "kelvin")
 	require.NoError(t, err)
 
 	assert.Equal(t, 273.15, resp.OriginalValue)
@@ -238,10 +240,92 @@ func TestConvertTemperature_ExtremeValues(t *testing.T) {
 				ToUnit:   tc.toUnit,
 			}
 
-			resp, err := service.ConvertTemperature(req)
+			resp, err := service.ConvertTemperature(context.Background(), req)
 			require.NoError(t, err)
 
 			assert.Equal(t, tc.expected, resp.ConvertedValue)
 		})
 	}
 }
+
+func TestConvertTemperature_UnknownUnit(t *testing.T) {
+	service := NewTemperatureService()
+
+	_, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:    25.0,
+		FromUnit: "invalid",
+		ToUnit:   "celsius",
+	})
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+
+	_, err = service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:    25.0,
+		FromUnit: "celsius",
+		ToUnit:   "invalid",
+	})
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+
+	_, err = service.GetAllConversions(context.Background(), 25.0, "invalid")
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+}
+
+func TestConvertTemperature_BelowAbsoluteZero(t *testing.T) {
+	service := NewTemperatureService()
+
+	testCases := []struct {
+		value    float64
+		fromUnit string
+	}{
+		{-500.0, "kelvin"},
+		{-273.16, "celsius"},
+		{-460.0, "fahrenheit"},
+		{-1.0, "rankine"},
+		{560.0, "delisle"},
+	}
+
+	for _, tc := range testCases {
+		_, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+			Value:    tc.value,
+			FromUnit: tc.fromUnit,
+			ToUnit:   "celsius",
+		})
+		assert.ErrorIs(t, err, ErrBelowAbsoluteZero, tc.fromUnit)
+	}
+}
+
+func TestConvertTemperature_NonFiniteValue(t *testing.T) {
+	service := NewTemperatureService()
+
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+			Value:    value,
+			FromUnit: "celsius",
+			ToUnit:   "kelvin",
+		})
+		assert.ErrorIs(t, err, ErrNonFiniteValue)
+	}
+}
+
+func TestConvertTemperature_Overflow(t *testing.T) {
+	service := NewTemperatureService()
+
+	// 1e308 °C é finito, mas 1e308 × 9/5 + 32 passa do maior float64
+	_, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:    1e308,
+		FromUnit: "celsius",
+		ToUnit:   "fahrenheit",
+	})
+	require.ErrorIs(t, err, ErrNonFiniteValue)
+
+	_, err = service.GetAllConversions(context.Background(), 1e308, "celsius")
+	require.ErrorIs(t, err, ErrNonFiniteValue)
+
+	// Sem estouro, valores grandes continuam aceitos
+	resp, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value:    1e308,
+		FromUnit: "fahrenheit",
+		ToUnit:   "celsius",
+	})
+	require.NoError(t, err)
+	assert.False(t, math.IsInf(resp.ConvertedValue, 0))
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// absoluteZeroOffset é a diferença entre Kelvin e Celsius.
+const absoluteZeroOffset = 273.15
+
+// FormulaTemplate descreve uma escala linear pela leitura no ponto de fusão
+// do gelo (273.15 K) e pelo número de graus da escala por kelvin. A partir
+// desses dois valores, a fórmula entre quaisquer duas escalas é derivada.
+type FormulaTemplate struct {
+	IcePoint         float64
+	DegreesPerKelvin *big.Rat
+}
+
+// TemperatureUnit representa uma escala de temperatura registrada.
+type TemperatureUnit struct {
+	Name       string
+	Symbol     string
+	ToKelvin   func(value float64) float64
+	FromKelvin func(kelvin float64) float64
+	Formula    FormulaTemplate
+}
+
+// NewLinearUnit cria uma escala linear a partir do ponto de fusão do gelo e
+// da razão graus/kelvin (num/den), derivando as funções de conversão.
+func NewLinearUnit(name, symbol string, icePoint float64, num, den int64) TemperatureUnit {
+	ratio := big.NewRat(num, den)
+	factor, _ := ratio.Float64()
+
+	return TemperatureUnit{
+		Name:   name,
+		Symbol: symbol,
+		ToKelvin: func(value float64) float64 {
+			return (value-icePoint)/factor + absoluteZeroOffset
+		},
+		FromKelvin: func(kelvin float64) float64 {
+			return (kelvin-absoluteZeroOffset)*factor + icePoint
+		},
+		Formula: FormulaTemplate{
+			IcePoint:         icePoint,
+			DegreesPerKelvin: ratio,
+		},
+	}
+}
+
+// UnitRegistry mantém as escalas de temperatura disponíveis.
+type UnitRegistry struct {
+	units map[string]TemperatureUnit
+	order []string
+}
+
+// NewUnitRegistry cria um registro vazio.
+func NewUnitRegistry() *UnitRegistry {
+	return &UnitRegistry{units: make(map[string]TemperatureUnit)}
+}
+
+// DefaultUnitRegistry cria um registro com as escalas suportadas pela API.
+func DefaultUnitRegistry() *UnitRegistry {
+	registry := NewUnitRegistry()
+
+	for _, unit := range []TemperatureUnit{
+		{
+			Name:       "celsius",
+			Symbol:     "°C",
+			ToKelvin:   func(value float64) float64 { return value + absoluteZeroOffset },
+			FromKelvin: func(kelvin float64) float64 { return kelvin - absoluteZeroOffset },
+			Formula:    FormulaTemplate{IcePoint: 0, DegreesPerKelvin: big.NewRat(1, 1)},
+		},
+		NewLinearUnit("fahrenheit", "°F", 32, 9, 5),
+		{
+			Name:       "kelvin",
+			Symbol:     "K",
+			ToKelvin:   func(value float64) float64 { return value },
+			FromKelvin: func(kelvin float64) float64 { return kelvin },
+			Formula:    FormulaTemplate{IcePoint: absoluteZeroOffset, DegreesPerKelvin: big.NewRat(1, 1)},
+		},
+		NewLinearUnit("rankine", "°R", 491.67, 9, 5),
+		NewLinearUnit("reaumur", "°Ré", 0, 4, 5),
+		NewLinearUnit("delisle", "°De", 150, -3, 2),
+		NewLinearUnit("newton", "°N", 0, 33, 100),
+		NewLinearUnit("romer", "°Rø", 7.5, 21, 40),
+	} {
+		if err := registry.Register(unit); err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
+
+// Register adiciona uma escala ao registro.
+func (r *UnitRegistry) Register(unit TemperatureUnit) error {
+	if unit.Name == "" || unit.ToKelvin == nil || unit.FromKelvin == nil {
+		return fmt.Errorf("invalid temperature unit %q", unit.Name) //nolint:wrapcheck
+	}
+
+	if unit.Formula.DegreesPerKelvin == nil || unit.Formula.DegreesPerKelvin.Sign() == 0 {
+		return fmt.Errorf("temperature unit %q needs a non-zero degrees per kelvin ratio", unit.Name) //nolint:wrapcheck
+	}
+
+	if _, exists := r.units[unit.Name]; exists {
+		return fmt.Errorf("temperature unit %q already registered", unit.Name) //nolint:wrapcheck
+	}
+
+	r.units[unit.Name] = unit
+	r.order = append(r.order, unit.Name)
+
+	return nil
+}
+
+// Get retorna a escala registrada com o nome informado.
+func (r *UnitRegistry) Get(name string) (TemperatureUnit, bool) {
+	unit, ok := r.units[name]
+	return unit, ok
+}
+
+// Has indica se a escala está registrada.
+func (r *UnitRegistry) Has(name string) bool {
+	_, ok := r.units[name]
+	return ok
+}
+
+// Names retorna os nomes das escalas na ordem de registro.
+func (r *UnitRegistry) Names() []string {
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+
+	return names
+}
+
+// Convert converte um valor entre duas escalas passando por Kelvin.
+func (r *UnitRegistry) Convert(value float64, from, to TemperatureUnit) float64 {
+	if from.Name == to.Name {
+		return value
+	}
+
+	return to.FromKelvin(from.ToKelvin(value))
+}
+
+// Formula monta a expressão de conversão de from para to, tendo operand como
+// valor de entrada (o símbolo da escala ou um número formatado).
+//
+// Para as escalas lineares, to = (from - gelo_from) × (razão_to / razão_from) + gelo_to.
+func (r *UnitRegistry) Formula(from, to TemperatureUnit, operand string) string {
+	ratio := new(big.Rat).Quo(to.Formula.DegreesPerKelvin, from.Formula.DegreesPerKelvin)
+	hasRatio := ratio.Cmp(big.NewRat(1, 1)) != 0
+	hasOffset := to.Formula.IcePoint != 0
+
+	expr := operand
+	if from.Formula.IcePoint != 0 {
+		expr = fmt.Sprintf("%s %s", operand, signedTerm(-from.Formula.IcePoint))
+		if hasRatio || hasOffset {
+			expr = "(" + expr + ")"
+		}
+	}
+
+	// Sem offset de entrada e de saída, a multiplicação dispensa parênteses;
+	// com offset de saída, ela é agrupada para deixar a precedência explícita
+	if hasRatio {
+		expr = fmt.Sprintf("%s × %s", expr, formatRatio(ratio))
+		if hasOffset && from.Formula.IcePoint == 0 {
+			expr = "(" + expr + ")"
+		}
+	}
+
+	if hasOffset {
+		expr = fmt.Sprintf("%s %s", expr, signedTerm(to.Formula.IcePoint))
+	}
+
+	return expr
+}
+
+// signedTerm formata um número como termo de soma: "+ 32" ou "- 273.15".
+func signedTerm(value float64) string {
+	if value < 0 {
+		return "- " + strconv.FormatFloat(-value, 'f', -1, 64)
+	}
+
+	return "+ " + strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatRatio formata uma razão como "9/5", "2" ou "(-5/6)".
+func formatRatio(ratio *big.Rat) string {
+	text := ratio.RatString()
+	if ratio.Sign() < 0 {
+		return "(" + text + ")"
+	}
+
+	return text
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultUnitRegistry_Names(t *testing.T) {
+	registry := DefaultUnitRegistry()
+
+	assert.Equal(t, []string{
+		"celsius", "fahrenheit", "kelvin", "rankine", "reaumur", "delisle", "newton", "romer",
+	}, registry.Names())
+}
+
+func TestConvertTemperature_AdditionalScales(t *testing.T) {
+	service := NewTemperatureService()
+
+	testCases := []struct {
+		name     string
+		value    float64
+		fromUnit string
+		toUnit   string
+		expected float64
+	}{
+		{"Boiling Point Celsius to Rankine", 100.0, "celsius", "rankine", 671.67},
+		{"Boiling Point Celsius to Reaumur", 100.0, "celsius", "reaumur", 80.0},
+		{"Boiling Point Celsius to Delisle", 100.0, "celsius", "delisle", 0.0},
+		{"Boiling Point Celsius to Newton", 100.0, "celsius", "newton", 33.0},
+		{"Boiling Point Celsius to Romer", 100.0, "celsius", "romer", 60.0},
+		{"Freezing Point Celsius to Delisle", 0.0, "celsius", "delisle", 150.0},
+		{"Freezing Point Celsius to Romer", 0.0, "celsius", "romer", 7.5},
+		{"Absolute Zero Kelvin to Rankine", 0.0, "kelvin", "rankine", 0.0},
+		{"Rankine to Fahrenheit", 491.67, "rankine", "fahrenheit", 32.0},
+		{"Delisle to Fahrenheit", 0.0, "delisle", "fahrenheit", 212.0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+				Value:    tc.value,
+				FromUnit: tc.fromUnit,
+				ToUnit:   tc.toUnit,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, resp.ConvertedValue)
+		})
+	}
+}
+
+func TestUnitRegistry_RoundTripAllPairs(t *testing.T) {
+	registry := DefaultUnitRegistry()
+
+	for _, fromName := range registry.Names() {
+		for _, toName := range registry.Names() {
+			from, _ := registry.Get(fromName)
+			to, _ := registry.Get(toName)
+
+			converted := registry.Convert(42.0, from, to)
+			back := registry.Convert(converted, to, from)
+
+			assert.InDelta(t, 42.0, back, 1e-9, "%s -> %s -> %s", fromName, toName, fromName)
+		}
+	}
+}
+
+func TestUnitRegistry_FormulaMatchesConversion(t *testing.T) {
+	registry := DefaultUnitRegistry()
+
+	from, _ := registry.Get("fahrenheit")
+	to, _ := registry.Get("delisle")
+
+	assert.Equal(t, "(°F - 32) × (-5/6) + 150", registry.Formula(from, to, from.Symbol))
+	assert.InDelta(t, 0.0, registry.Convert(212, from, to), 1e-9)
+}
+
+func TestUnitRegistry_Register(t *testing.T) {
+	registry := NewUnitRegistry()
+
+	require.NoError(t, registry.Register(NewLinearUnit("test", "°T", 10, 2, 1)))
+	assert.True(t, registry.Has("test"))
+
+	// Nome duplicado
+	require.Error(t, registry.Register(NewLinearUnit("test", "°T", 10, 2, 1)))
+
+	// Sem funções de conversão
+	require.Error(t, registry.Register(TemperatureUnit{
+		Name:    "broken",
+		Formula: FormulaTemplate{DegreesPerKelvin: big.NewRat(1, 1)},
+	}))
+
+	// Escala registrada participa de todas as conversões
+	registry = DefaultUnitRegistry()
+	require.NoError(t, registry.Register(NewLinearUnit("test", "°T", 10, 2, 1)))
+
+	service := NewTemperatureServiceWithRegistry(registry)
+	resp, err := service.GetAllConversions(context.Background(), 100, "celsius")
+	require.NoError(t, err)
+
+	assert.Equal(t, 210.0, resp.Conversions["test"])
+	assert.Equal(t, "°T = (°C × 2) + 10 = (100.00 × 2) + 10 = 210.00", resp.Formulas["test"])
+	assert.False(t, math.IsNaN(resp.Conversions["romer"]))
+}
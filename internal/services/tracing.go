@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer cria os spans dos serviços. Sem tracing configurado, o provider
+// global é no-op e os spans não têm custo relevante.
+var tracer = otel.Tracer("golang/internal/services")
+
+// startSpan inicia um span filho do span do contexto.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...)) //nolint:spancheck
+}
+
+// endSpan registra o erro, se houver, e encerra o span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTemperatureServiceSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	service := NewTemperatureService()
+
+	_, err := service.GetAllConversions(context.Background(), 100, "celsius")
+	require.NoError(t, err)
+
+	_, err = service.ConvertTemperature(context.Background(), &TemperatureConversionRequest{
+		Value: 1, FromUnit: "celsius", ToUnit: "unknown",
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.NotEmpty(t, spans)
+
+	var parent sdktrace.ReadOnlySpan
+
+	for _, span := range spans {
+		if span.Name() == "TemperatureService.GetAllConversions" {
+			parent = span
+		}
+	}
+
+	require.NotNil(t, parent)
+	assert.Contains(t, parent.Attributes(), attribute.String("temperature.from_unit", "celsius"))
+
+	children := 0
+
+	for _, span := range spans {
+		if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+			assert.Equal(t, "TemperatureService.ConvertTemperature", span.Name())
+
+			children++
+		}
+	}
+
+	assert.Equal(t, len(service.Units().Names())-1, children)
+
+	failed := spans[len(spans)-1]
+	assert.Equal(t, "Error", failed.Status().Code.String())
+	assert.NotEmpty(t, failed.Events())
+}
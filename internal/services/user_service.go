@@ -1,83 +1,173 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"golang/internal/auth"
 	"golang/internal/models"
-
-	"gorm.io/gorm"
+	"golang/internal/repository"
 )
 
+// ErrEmailAlreadyExists indica que o email já está em uso por outro usuário.
+var ErrEmailAlreadyExists = repository.ErrEmailAlreadyExists
+
+// ErrUserNotFound indica que o usuário não existe ou foi removido.
+var ErrUserNotFound = repository.ErrUserNotFound
+
+// ErrInvalidCredentials indica que email ou senha não conferem.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
 // UserService gerencia operações relacionadas a usuários.
 type UserService struct {
-	db *gorm.DB
+	users  repository.UserRepository
+	hasher auth.PasswordHasher
 }
 
 // NewUserService cria uma nova instância do UserService.
-func NewUserService(db *gorm.DB) *UserService {
-	return &UserService{db: db}
+func NewUserService(users repository.UserRepository, hasher auth.PasswordHasher) *UserService {
+	return &UserService{users: users, hasher: hasher}
 }
 
 // CreateUser cria um novo usuário.
-func (s *UserService) CreateUser(user *models.User) error {
-	// Verificar se o email já existe
-	var existingUser models.User
-	if err := s.db.Where("email = ?", user.Email).First(&existingUser).Error; err == nil {
-		return fmt.Errorf("email already exists: %w", errors.New("email already exists")) //nolint:wrapcheck
+func (s *UserService) CreateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := startSpan(ctx, "UserService.CreateUser")
+	defer func() { endSpan(span, err) }()
+
+	// Verificar se o email já existe antes de gerar o hash da senha
+	taken, err := s.users.EmailTaken(ctx, user.Email, 0)
+	if err != nil {
+		return err //nolint:wrapcheck
 	}
 
-	// TODO: Hash da senha antes de salvar
-	// user.Password = hashPassword(user.Password)
+	if taken {
+		return ErrEmailAlreadyExists
+	}
 
-	return s.db.Create(user).Error
+	if err := s.SetPassword(user, user.Password); err != nil {
+		return err
+	}
+
+	return s.users.Create(ctx, user) //nolint:wrapcheck
 }
 
-// GetUserByID busca um usuário pelo ID.
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	var user models.User
-	if err := s.db.First(&user, id).Error; err != nil {
-		return nil, err
+// SetPassword gera o hash da senha em texto puro e o atribui ao usuário.
+// A alteração só é persistida ao salvar o usuário.
+func (s *UserService) SetPassword(user *models.User, plain string) error {
+	hash, err := s.hasher.Hash(plain)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err) //nolint:wrapcheck
 	}
 
-	return &user, nil
+	user.Password = hash
+
+	return nil
 }
 
-// GetUserByEmail busca um usuário pelo email.
-func (s *UserService) GetUserByEmail(email string) (*models.User, error) {
-	var user models.User
-	if err := s.db.Where("email = ?", email).First(&user).Error; err != nil {
+// VerifyPassword autentica um usuário por email e senha. Se o hash armazenado
+// usar parâmetros desatualizados, ele é refeito com os parâmetros atuais.
+func (s *UserService) VerifyPassword(ctx context.Context, email, plain string) (_ *models.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.VerifyPassword")
+	defer func() { endSpan(span, err) }()
+
+	user, err := s.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			// Gerar um hash mesmo assim para não revelar, pelo tempo de resposta,
+			// se o email existe
+			_, _ = s.hasher.Hash(plain)
+			return nil, ErrInvalidCredentials
+		}
+
 		return nil, err
 	}
 
-	return &user, nil
+	ok, err := s.hasher.Verify(user.Password, plain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify password: %w", err) //nolint:wrapcheck
+	}
+
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	if s.hasher.NeedsRehash(user.Password) {
+		if err := s.rehashPassword(ctx, user, plain); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
 }
 
-// UpdateUser atualiza um usuário.
-func (s *UserService) UpdateUser(user *models.User) error {
-	return s.db.Save(user).Error
+// rehashPassword atualiza o hash armazenado com os parâmetros atuais.
+func (s *UserService) rehashPassword(ctx context.Context, user *models.User, plain string) error {
+	if err := s.SetPassword(user, plain); err != nil {
+		return err
+	}
+
+	if err := s.users.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		return fmt.Errorf("failed to rehash password: %w", err) //nolint:wrapcheck
+	}
+
+	return nil
 }
 
-// DeleteUser remove um usuário (soft delete).
-func (s *UserService) DeleteUser(id uint) error {
-	return s.db.Delete(&models.User{}, id).Error
+// GetUserByID busca um usuário pelo ID.
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (_ *models.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserByID")
+	defer func() { endSpan(span, err) }()
+
+	return s.users.FindByID(ctx, id) //nolint:wrapcheck
 }
 
-// ListUsers lista todos os usuários com paginação.
-func (s *UserService) ListUsers(offset, limit int) ([]models.User, int64, error) {
-	var users []models.User
+// GetUserWithPermissions busca um usuário pelo ID carregando papéis e permissões.
+func (s *UserService) GetUserWithPermissions(ctx context.Context, id uint) (_ *models.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserWithPermissions")
+	defer func() { endSpan(span, err) }()
+
+	return s.users.FindByIDWithPermissions(ctx, id) //nolint:wrapcheck
+}
+
+// GetUserByEmail busca um usuário pelo email.
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (_ *models.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserByEmail")
+	defer func() { endSpan(span, err) }()
 
-	var total int64
+	return s.users.FindByEmail(ctx, email) //nolint:wrapcheck
+}
 
-	// Contar total de registros
-	if err := s.db.Model(&models.User{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+// UpdateUser atualiza um usuário.
+func (s *UserService) UpdateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := startSpan(ctx, "UserService.UpdateUser")
+	defer func() { endSpan(span, err) }()
+
+	// Verificar se o novo email pertence a outro usuário
+	taken, err := s.users.EmailTaken(ctx, user.Email, user.ID)
+	if err != nil {
+		return err //nolint:wrapcheck
 	}
 
-	// Buscar usuários com paginação
-	if err := s.db.Offset(offset).Limit(limit).Find(&users).Error; err != nil {
-		return nil, 0, err
+	if taken {
+		return ErrEmailAlreadyExists
 	}
 
-	return users, total, nil
+	return s.users.Update(ctx, user) //nolint:wrapcheck
+}
+
+// DeleteUser remove um usuário (soft delete).
+func (s *UserService) DeleteUser(ctx context.Context, id uint) (err error) {
+	ctx, span := startSpan(ctx, "UserService.DeleteUser")
+	defer func() { endSpan(span, err) }()
+
+	return s.users.Delete(ctx, id) //nolint:wrapcheck
+}
+
+// ListUsers lista todos os usuários com paginação.
+func (s *UserService) ListUsers(ctx context.Context, offset, limit int) (_ []models.User, _ int64, err error) {
+	ctx, span := startSpan(ctx, "UserService.ListUsers")
+	defer func() { endSpan(span, err) }()
+
+	return s.users.List(ctx, offset, limit) //nolint:wrapcheck
 }
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"golang/internal/auth"
+	"golang/internal/models"
+	"golang/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestUserService(t *testing.T) (*UserService, *repository.MemoryUserRepository) {
+	t.Helper()
+
+	users := repository.NewMemoryUserRepository()
+
+	return NewUserService(users, auth.NewBcryptHasher(bcrypt.MinCost)), users
+}
+
+func TestUserServiceCreateUser(t *testing.T) {
+	service, users := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"}
+	require.NoError(t, service.CreateUser(ctx, user))
+	assert.NotEqual(t, "Secret123!", user.Password, "the password is stored hashed")
+
+	stored, err := users.FindByEmail(ctx, "ana@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, stored.ID)
+
+	err = service.CreateUser(ctx, &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"})
+	require.ErrorIs(t, err, ErrEmailAlreadyExists)
+}
+
+func TestUserServiceVerifyPassword(t *testing.T) {
+	service, users := newTestUserService(t)
+	ctx := context.Background()
+
+	user := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"}
+	require.NoError(t, service.CreateUser(ctx, user))
+
+	verified, err := service.VerifyPassword(ctx, "ana@example.com", "Secret123!")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, verified.ID)
+
+	_, err = service.VerifyPassword(ctx, "ana@example.com", "wrong")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = service.VerifyPassword(ctx, "nobody@example.com", "Secret123!")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	t.Run("rehashes outdated hashes", func(t *testing.T) {
+		stronger := NewUserService(users, auth.NewBcryptHasher(bcrypt.MinCost+1))
+
+		_, err := stronger.VerifyPassword(ctx, "ana@example.com", "Secret123!")
+		require.NoError(t, err)
+
+		stored, err := users.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+
+		cost, err := bcrypt.Cost([]byte(stored.Password))
+		require.NoError(t, err)
+		assert.Equal(t, bcrypt.MinCost+1, cost)
+	})
+}
+
+func TestUserServiceUpdateUser(t *testing.T) {
+	service, _ := newTestUserService(t)
+	ctx := context.Background()
+
+	ana := &models.User{Email: "ana@example.com", Name: "Ana", Password: "Secret123!"}
+	require.NoError(t, service.CreateUser(ctx, ana))
+	require.NoError(t, service.CreateUser(ctx, &models.User{Email: "bia@example.com", Name: "Bia", Password: "Secret123!"}))
+
+	ana.Name = "Ana Maria"
+	require.NoError(t, service.UpdateUser(ctx, ana), "keeping the own email is allowed")
+
+	ana.Email = "bia@example.com"
+	require.ErrorIs(t, service.UpdateUser(ctx, ana), ErrEmailAlreadyExists)
+
+	updated, err := service.GetUserByID(ctx, ana.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ana Maria", updated.Name)
+	assert.Equal(t, "ana@example.com", updated.Email)
+}
+
+func TestUserServiceDeleteAndList(t *testing.T) {
+	service, _ := newTestUserService(t)
+	ctx := context.Background()
+
+	for _, email := range []string{"ana@example.com", "bia@example.com", "caio@example.com"} {
+		require.NoError(t, service.CreateUser(ctx, &models.User{Email: email, Name: email, Password: "Secret123!"}))
+	}
+
+	require.NoError(t, service.DeleteUser(ctx, 1))
+
+	_, err := service.GetUserByID(ctx, 1)
+	require.ErrorIs(t, err, ErrUserNotFound)
+
+	users, total, err := service.ListUsers(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, users, 2)
+	assert.Equal(t, "bia@example.com", users[0].Email)
+}
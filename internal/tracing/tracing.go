@@ -0,0 +1,101 @@
+// Package tracing configura o OpenTelemetry: exportador, amostragem e
+// propagação W3C Trace Context.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exportadores suportados.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// ErrUnknownExporter indica um exportador não suportado.
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// ShutdownFunc envia os spans pendentes e libera o exportador.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup instala o propagador W3C Trace Context e, se o tracing estiver
+// habilitado, o TracerProvider global com o exportador configurado. O
+// exportador stdout escreve em out (os.Stdout se nil). Com o tracing
+// desabilitado, os spans são no-op e a ShutdownFunc não faz nada.
+func Setup(ctx context.Context, cfg config.TracingConfig, out io.Writer) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg, out)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err) //nolint:wrapcheck
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// newExporter cria o exportador de spans.
+func newExporter(ctx context.Context, cfg config.TracingConfig, out io.Writer) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err) //nolint:wrapcheck
+		}
+
+		return exporter, nil
+	case ExporterStdout:
+		if out == nil {
+			out = os.Stdout
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err) //nolint:wrapcheck
+		}
+
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter) //nolint:wrapcheck
+	}
+}
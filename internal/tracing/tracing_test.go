@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"golang/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetupStdout(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	var out bytes.Buffer
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{
+		Enabled:     true,
+		Exporter:    ExporterStdout,
+		ServiceName: "test-service",
+		SampleRatio: 1,
+	}, &out)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Contains(t, out.String(), `"Name":"operation"`)
+	assert.Contains(t, out.String(), "test-service")
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: "unknown"}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "zipkin"}, nil)
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+}
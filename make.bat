@@ -4,9 +4,14 @@ setlocal
 set BINARY_NAME=golang-server
 set BUILD_DIR=build
 set MAIN_PATH=./cmd/server
+set MIGRATE_PATH=./cmd/migrate
 
 if "%1"=="build" goto build
 if "%1"=="run" goto run
+if "%1"=="migrate-up" goto migrate-up
+if "%1"=="migrate-down" goto migrate-down
+if "%1"=="migrate-status" goto migrate-status
+if "%1"=="migrate-create" goto migrate-create
 if "%1"=="test" goto test
 if "%1"=="test-coverage" goto test-coverage
 if "%1"=="clean" goto clean
@@ -34,6 +39,25 @@ echo Running application...
 go run %MAIN_PATH%
 goto end
 
+:migrate-up
+echo Applying migrations...
+go run %MIGRATE_PATH% up
+goto end
+
+:migrate-down
+echo Reverting last migration...
+go run %MIGRATE_PATH% down
+goto end
+
+:migrate-status
+echo Migration status:
+go run %MIGRATE_PATH% status
+goto end
+
+:migrate-create
+go run %MIGRATE_PATH% create %2
+goto end
+
 :test
 echo Running tests...
 go test -v ./...
@@ -95,6 +119,10 @@ goto end
 echo Available commands:
 echo   build         - Build the application
 echo   run           - Run the application
+echo   migrate-up    - Apply pending database migrations
+echo   migrate-down  - Revert the last database migration
+echo   migrate-status - Show database migration status
+echo   migrate-create - Create a migration (make.bat migrate-create name)
 echo   test          - Run tests
 echo   test-coverage - Run tests with coverage report
 echo   clean         - Clean build files
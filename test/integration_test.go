@@ -8,7 +8,7 @@ import (
 
 	"golang/internal/api"
 	"golang/internal/config"
-	"golang/internal/database"
+	"golang/internal/database/dbtest"
 	"golang/internal/middleware"
 
 	"github.com/stretchr/testify/assert"
@@ -22,36 +22,19 @@ type TestServer struct {
 	DB     *gorm.DB
 }
 
-// NewTestServer cria um novo servidor para testes
+// NewTestServer cria um novo servidor para testes, com um banco exclusivo
+// do teste (SQLite por padrão; veja o pacote dbtest)
 func NewTestServer(t *testing.T) *TestServer {
 	// Configuração de teste
-	cfg := &config.Config{
-		Server: config.ServerConfig{
-			Port:         "8080",
-			ReadTimeout:  30,
-			WriteTimeout: 30,
-			IdleTimeout:  60,
-		},
-		Database: config.DatabaseConfig{
-			Host:     "localhost",
-			Port:     "5432",
-			User:     "postgres",
-			Password: "password",
-			DBName:   "golang_test",
-			SSLMode:  "disable",
-		},
-		Log: config.LogConfig{
-			Level: "error", // Reduzir logs durante testes
-		},
-	}
-
-	// Conectar ao banco de dados de teste
-	db, err := database.Connect(cfg.Database)
-	require.NoError(t, err)
+	cfg := config.Default()
+	cfg.Log.Level = "error" // Reduzir logs durante testes
 
 	// Criar logger
 	logger := middleware.NewLogger()
 
+	// Conectar ao banco de dados de teste, já migrado
+	db := dbtest.Open(t)
+
 	// Criar servidor
 	server := api.NewServer(cfg, db, logger)
 
@@ -64,7 +47,6 @@ func NewTestServer(t *testing.T) *TestServer {
 // TestHealthCheck testa o endpoint de health check
 func TestHealthCheck(t *testing.T) {
 	ts := NewTestServer(t)
-	defer database.Close(ts.DB)
 
 	// Criar requisição
 	req, err := http.NewRequestWithContext(context.Background(), "GET", "/health", http.NoBody)
@@ -84,7 +66,6 @@ func TestHealthCheck(t *testing.T) {
 // TestHelloEndpoint testa o endpoint hello
 func TestHelloEndpoint(t *testing.T) {
 	ts := NewTestServer(t)
-	defer database.Close(ts.DB)
 
 	// Criar requisição
 	req, err := http.NewRequestWithContext(context.Background(), "GET", "/api/v1/hello", http.NoBody)